@@ -0,0 +1,200 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+var (
+	ErrMissingParameter = errors.New("missing parameter")
+	ErrUnexpectedTail   = errors.New("unexpected tail")
+)
+
+type PatternJoiner interface {
+	Join([]string) (string, error)
+}
+
+type PatternJoinerFunc func([]string) (string, error)
+
+func (pjf PatternJoinerFunc) Join(segs []string) (string, error) { return pjf(segs) }
+
+func joinHostDefault(segs []string) (string, error) {
+	var (
+		nSegs = len(segs)
+		res   = make([]string, nSegs)
+	)
+
+	for i, seg := range segs {
+		if seg == "" || strings.ContainsRune(seg, segHostSep) {
+			return "", fmt.Errorf("%w: invalid label '%s'", ErrInvalidHost, seg)
+		}
+
+		res[(nSegs-1)-i] = seg
+	}
+
+	return strings.Join(res, string(segHostSep)), nil
+}
+
+// NOTE: A lone IP literal segment, as produced by StrictHostSegmenter, is
+// joined as is.
+func joinHostStrict(segs []string) (string, error) {
+	if len(segs) == 1 {
+		literal := strings.TrimSuffix(strings.TrimPrefix(segs[0], "["), "]")
+
+		if _, err := netip.ParseAddr(literal); err == nil {
+			return segs[0], nil
+		}
+	}
+
+	return joinHostDefault(segs)
+}
+
+func formatHostDefault(segs []string) (string, error) {
+	var (
+		nSegs = len(segs)
+		res   = make([]string, nSegs)
+	)
+
+	for i, seg := range segs {
+		res[(nSegs-1)-i] = seg
+	}
+
+	return strings.Join(res, string(segHostSep)), nil
+}
+
+func formatPathDefault(segs []string) (string, error) {
+	return string(segPathSep) + strings.Join(segs, string(segPathSep)), nil
+}
+
+func joinPathDefault(segs []string) (string, error) {
+	res := make([]string, len(segs))
+
+	for i, seg := range segs {
+		if seg == "" {
+			return "", fmt.Errorf("%w: empty segment", ErrInvalidPath)
+		}
+
+		res[i] = url.PathEscape(seg)
+	}
+
+	return string(segPathSep) + strings.Join(res, string(segPathSep)), nil
+}
+
+func joinPathTrailing(segs []string) (string, error) {
+	if last := len(segs) - 1; last >= 0 && segs[last] == "" {
+		res, err := joinPathDefault(segs[:last])
+		if err != nil || res == string(segPathSep) {
+			return res, err
+		}
+
+		return res + string(segPathSep), nil
+	}
+
+	return joinPathDefault(segs)
+}
+
+func BuildSegments[P comparable](keys []graph.Key, params map[P]string, tail ...string) ([]string, error) {
+	return buildSegments(keys, params, nil, tail)
+}
+
+func buildSegments[P comparable](keys []graph.Key, params map[P]string, check func(string) error, tail []string) ([]string, error) {
+	if check != nil {
+		for _, val := range tail {
+			if err := check(val); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var (
+		res      = make([]string, 0, len(keys)+len(tail))
+		wildcard bool
+	)
+
+	for _, key := range keys {
+		switch t := key.(type) {
+		case graph.KeyConstant:
+			res = append(res, string(t))
+		case graph.KeyParameter[P]:
+			val, ok := params[t.Name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrMissingParameter, t.Name)
+			}
+
+			if check != nil {
+				if err := check(val); err != nil {
+					return nil, err
+				}
+			}
+
+			res = append(res, val)
+		case graph.KeyWildcard:
+			res, wildcard = append(res, tail...), true
+		case nil:
+			return nil, graph.ErrNilKey
+		default:
+			return nil, fmt.Errorf("%w: unexpected key type %T", graph.ErrInvalidKey, key)
+		}
+	}
+
+	if len(tail) > 0 && !wildcard {
+		return nil, ErrUnexpectedTail
+	}
+
+	return res, nil
+}
+
+func (r *Router[V, P]) BuildKeys(keys []graph.Key, params map[P]string, tail ...string) (string, error) {
+	segs, err := buildSegments(keys, params, r.ValueChecker, tail)
+	if err != nil {
+		return "", err
+	}
+
+	return r.Joiner.Join(segs)
+}
+
+func (r *Router[V, P]) Build(pattern string, params map[P]string, tail ...string) (string, error) {
+	keys, err := r.keys(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return r.BuildKeys(keys, params, tail...)
+}
+
+func PatternSegments[P comparable](keys []graph.Key) ([]string, error) {
+	res := make([]string, len(keys))
+
+	for i, key := range keys {
+		switch t := key.(type) {
+		case graph.KeyConstant:
+			res[i] = string(t)
+		case graph.KeyParameter[P]:
+			res[i] = fmt.Sprintf("%c%v", decPrefixParam, t.Name)
+		case graph.KeyWildcard:
+			res[i] = string(decPrefixWild)
+		case nil:
+			return nil, graph.ErrNilKey
+		default:
+			return nil, fmt.Errorf("%w: unexpected key type %T", graph.ErrInvalidKey, key)
+		}
+	}
+
+	return res, nil
+}
+
+// Pattern formats keys (typically SearchResult.Path) back into a pattern
+// string, suitable as a low cardinality route label
+func (r *Router[V, P]) Pattern(keys []graph.Key) (string, error) {
+	segs, err := PatternSegments[P](keys)
+	if err != nil {
+		return "", err
+	}
+
+	return r.Formatter.Join(segs)
+}
@@ -0,0 +1,155 @@
+package component_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/component"
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentBuildSuccess(t *testing.T) {
+	var (
+		host = component.NewHostRouter[string]()
+		path = component.NewPathRouter[string]()
+	)
+
+	subtests := []struct {
+		name     string
+		router   *component.Router[string, string]
+		pattern  string
+		params   map[string]string
+		tail     []string
+		expected string
+	}{
+		{
+			name:     "host constant",
+			router:   host,
+			pattern:  "a.b.com",
+			expected: "a.b.com",
+		},
+		{
+			name:     "host parameter",
+			router:   host,
+			pattern:  ":sub.b.:tld",
+			params:   map[string]string{"sub": "x", "tld": "org"},
+			expected: "x.b.org",
+		},
+		{
+			name:     "host wildcard",
+			router:   host,
+			pattern:  "*any.b.com",
+			tail:     []string{"y", "x"},
+			expected: "x.y.b.com",
+		},
+		{
+			name:     "path root",
+			router:   path,
+			pattern:  "/",
+			expected: "/",
+		},
+		{
+			name:     "path parameter",
+			router:   path,
+			pattern:  "/users/:id/posts/:post",
+			params:   map[string]string{"id": "a/b", "post": "x y"},
+			expected: "/users/a%2Fb/posts/x%20y",
+		},
+		{
+			name:     "path wildcard",
+			router:   path,
+			pattern:  "/static/*any",
+			tail:     []string{"css", "main.css"},
+			expected: "/static/css/main.css",
+		},
+		{
+			name:     "path empty wildcard",
+			router:   path,
+			pattern:  "/static/*any",
+			expected: "/static",
+		},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			actual, err := st.router.Build(st.pattern, st.params, st.tail...)
+
+			require.NoError(t, err)
+			assert.Equal(t, st.expected, actual)
+		})
+	}
+}
+
+func TestComponentBuildError(t *testing.T) {
+	var (
+		host = component.NewHostRouter[string]()
+		path = component.NewPathRouter[string]()
+	)
+
+	_, err := path.Build("/users/:id", nil)
+	assert.ErrorIs(t, err, component.ErrMissingParameter)
+
+	_, err = path.Build("/users/:id", map[string]string{"id": ""})
+	assert.ErrorIs(t, err, component.ErrInvalidPath)
+
+	_, err = path.Build("/users", nil, "extra")
+	assert.ErrorIs(t, err, component.ErrUnexpectedTail)
+
+	_, err = path.BuildKeys([]graph.Key{graph.KeyConstant("a"), nil}, nil)
+	assert.ErrorIs(t, err, graph.ErrNilKey)
+
+	for _, value := range []string{"", "x.y", "x/y", "x:80", "user@x", "x y", "-x", strings.Repeat("x", 64)} {
+		_, err = host.Build(":sub.com", map[string]string{"sub": value})
+		assert.ErrorIs(t, err, component.ErrInvalidHost, value)
+	}
+
+	_, err = host.Build("*.com", nil, "evil.com/x")
+	assert.ErrorIs(t, err, component.ErrInvalidHost)
+
+	actual, err := host.Build("localhost:8080", nil)
+	require.NoError(t, err, "check constants unvalidated")
+	assert.Equal(t, "localhost:8080", actual)
+}
+
+func TestComponentPattern(t *testing.T) {
+	var (
+		host = component.NewHostRouter[string]()
+		path = component.NewPathRouter[string]()
+	)
+
+	subtests := []struct {
+		name    string
+		router  *component.Router[string, string]
+		pattern string
+		query   string
+	}{
+		{name: "host", router: host, pattern: ":sub.b.com", query: "a.b.com"},
+		{name: "host wildcard", router: host, pattern: "*.com", query: "a.c.com"},
+		{name: "path", router: path, pattern: "/users/:id", query: "/users/123"},
+		{name: "path wildcard", router: path, pattern: "/static/*", query: "/static/a/b"},
+		{name: "path root", router: path, pattern: "/", query: "/"},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			require.NoError(t, st.router.Add(st.pattern, st.name))
+
+			var result *graph.SearchResult[string, string]
+			require.NoError(t, st.router.SearchFunc(func(r *graph.SearchResult[string, string]) bool {
+				result = r
+				return true
+			}, st.query))
+			require.NotNil(t, result)
+
+			actual, err := st.router.Pattern(result.Path)
+			require.NoError(t, err)
+			assert.Equal(t, st.pattern, actual)
+		})
+	}
+}
@@ -4,15 +4,47 @@ import "github.com/oligarch316/go-urlrouter/graph/priority"
 
 var (
 	DefaultKeyDecoder    KeyDecodeFunc        = decodeKeyDefault
+	DefaultHostFormatter PatternJoinerFunc    = formatHostDefault
+	DefaultHostJoiner    PatternJoinerFunc    = joinHostDefault
 	DefaultHostSegmenter PatternSegmenterFunc = segmentHostDefault
+	DefaultPathFormatter PatternJoinerFunc    = formatPathDefault
+	DefaultPathJoiner    PatternJoinerFunc    = joinPathDefault
 	DefaultPathSegmenter PatternSegmenterFunc = segmentPathDefault
+
+	StrictHostJoiner         PatternJoinerFunc    = joinHostStrict
+	StrictHostSegmenter      PatternSegmenterFunc = segmentHostStrict
+	StrictHostQuerySegmenter PatternSegmenterFunc = segmentHostStrictQuery
+
+	TrailingSlashPathJoiner    PatternJoinerFunc    = joinPathTrailing
+	TrailingSlashPathSegmenter PatternSegmenterFunc = segmentPathTrailing
 )
 
-func NewHostRouter[V any](opts ...func(*Router[V])) *Router[V] {
-	res := &Router[V]{
-		Decoder:   DefaultKeyDecoder,
-		Segmenter: DefaultHostSegmenter,
-		Tree:      new(priority.Tree[V]),
+type routerDefaults struct {
+	decoder           KeyDecoder
+	formatter, joiner PatternJoiner
+	segmenter         PatternSegmenter
+	valueChecker      func(string) error
+}
+
+// NOTE: Built host values are held to the labels of StrictHostSegmenter, lest a
+// value such as "evil.com/x" or "user@evil.com" alter the built host
+func hostDefaults(decoder KeyDecoder) routerDefaults {
+	return routerDefaults{decoder, DefaultHostFormatter, DefaultHostJoiner, DefaultHostSegmenter, checkHostLabel}
+}
+
+func pathDefaults(decoder KeyDecoder) routerDefaults {
+	return routerDefaults{decoder, DefaultPathFormatter, DefaultPathJoiner, DefaultPathSegmenter, nil}
+}
+
+func newRouter[V any, P comparable](defaults routerDefaults, opts []func(*Router[V, P])) *Router[V, P] {
+	res := &Router[V, P]{
+		Decoder:   defaults.decoder,
+		Formatter: defaults.formatter,
+		Joiner:    defaults.joiner,
+		Segmenter: defaults.segmenter,
+		Tree:      new(priority.Tree[V, P]),
+
+		ValueChecker: defaults.valueChecker,
 	}
 
 	for _, opt := range opts {
@@ -22,16 +54,72 @@ func NewHostRouter[V any](opts ...func(*Router[V])) *Router[V] {
 	return res
 }
 
-func NewPathRouter[V any](opts ...func(*Router[V])) *Router[V] {
-	res := &Router[V]{
-		Decoder:   DefaultKeyDecoder,
-		Segmenter: DefaultPathSegmenter,
-		Tree:      new(priority.Tree[V]),
+func NewHostRouter[V any](opts ...func(*Router[V, string])) *Router[V, string] {
+	return newRouter(hostDefaults(DefaultKeyDecoder), opts)
+}
+
+func NewPathRouter[V any](opts ...func(*Router[V, string])) *Router[V, string] {
+	return newRouter(pathDefaults(DefaultKeyDecoder), opts)
+}
+
+func NewIndexedHostRouter[V any](opts ...func(*Router[V, int])) *Router[V, int] {
+	return newRouter(hostDefaults(IndexKeyDecoder{Decoder: DefaultKeyDecoder}), opts)
+}
+
+func NewIndexedPathRouter[V any](opts ...func(*Router[V, int])) *Router[V, int] {
+	return newRouter(pathDefaults(IndexKeyDecoder{Decoder: DefaultKeyDecoder}), opts)
+}
+
+// StrictHost configures a host router to strip ports, ignore case and a
+// trailing root dot, reject invalid labels and match IP literals as a whole.
+// Queries are further denied parameter and wildcard labels.
+func StrictHost[V any, P comparable](r *Router[V, P]) {
+	r.Joiner = StrictHostJoiner
+	r.Segmenter = StrictHostSegmenter
+	r.QuerySegmenter = StrictHostQuerySegmenter
+}
+
+// NOTE: Path options replace or wrap the base segmenter beneath any escaping and
+// cleaning already applied, so that they may be given in any order.
+func mapBaseSegmenter(segmenter PatternSegmenter, fn func(PatternSegmenter) PatternSegmenter) PatternSegmenter {
+	switch t := segmenter.(type) {
+	case EscapedPathSegmenter:
+		t.Segmenter = mapBaseSegmenter(t.Segmenter, fn)
+		return t
+	case CleanPathSegmenter:
+		t.Segmenter = mapBaseSegmenter(t.Segmenter, fn)
+		return t
 	}
 
-	for _, opt := range opts {
-		opt(res)
+	return fn(segmenter)
+}
+
+// DistinctTrailingSlash configures a path router to treat "/a" and "/a/" as
+// distinct patterns and queries.
+func DistinctTrailingSlash[V any, P comparable](r *Router[V, P]) {
+	r.Decoder = TrailingSlashDecoder{Decoder: r.Decoder}
+	r.Joiner = TrailingSlashPathJoiner
+	r.Segmenter = mapBaseSegmenter(r.Segmenter, func(PatternSegmenter) PatternSegmenter {
+		return TrailingSlashPathSegmenter
+	})
+}
+
+// EscapedPath configures a router to accept escaped patterns and queries, such
+// as from url.URL.EscapedPath, unescaping each segment after splitting.
+func EscapedPath[V any, P comparable](r *Router[V, P]) {
+	if _, ok := r.Segmenter.(EscapedPathSegmenter); ok {
+		return
 	}
 
-	return res
+	r.Segmenter = EscapedPathSegmenter{Segmenter: r.Segmenter}
+}
+
+// CleanPath configures a router to resolve "." and ".." segments and ignore
+// empty ones in both patterns and queries.
+func CleanPath[V any, P comparable](r *Router[V, P]) {
+	// NOTE: Cleaning escaped segments leaves an encoded "%2E%2E" as data rather
+	// than resolving it as a parent reference
+	r.Segmenter = mapBaseSegmenter(r.Segmenter, func(base PatternSegmenter) PatternSegmenter {
+		return CleanPathSegmenter{Segmenter: base}
+	})
 }
@@ -1,6 +1,7 @@
 package component_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -26,7 +27,7 @@ func (ri RouteItem) String() string {
 
 type Tree struct{ graphtest.Tree }
 
-func (t *Tree) AsOption(r *component.Router[string]) { r.Tree = t }
+func (t *Tree) AsOption(r *component.Router[string, string]) { r.Tree = t }
 
 func Route(pattern, value string) RouteItem {
 	return RouteItem{pattern: pattern, value: value}
@@ -58,17 +59,17 @@ func TestComponentRouterHostSearch(t *testing.T) {
 
 	searchTests := []struct {
 		query    Query
-		expected graph.SearchResult[string]
+		expected graph.SearchResult[string, string]
 	}{
 		{
 			query: "a.b.com",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valCom1",
 			},
 		},
 		{
 			query: "x.b.com",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valCom2",
 				Parameters: map[string]string{
 					"some": "x",
@@ -77,14 +78,14 @@ func TestComponentRouterHostSearch(t *testing.T) {
 		},
 		{
 			query: "x.y.b.com",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valCom3",
 				Tail:  []string{"y", "x"},
 			},
 		},
 		{
 			query: "a.b.org",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valOther1",
 				Parameters: map[string]string{
 					"other": "org",
@@ -93,7 +94,7 @@ func TestComponentRouterHostSearch(t *testing.T) {
 		},
 		{
 			query: "x.b.org",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valOther2",
 				Parameters: map[string]string{
 					"some":  "x",
@@ -103,7 +104,7 @@ func TestComponentRouterHostSearch(t *testing.T) {
 		},
 		{
 			query: "x.y.b.org",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valOther3",
 				Parameters: map[string]string{
 					"other": "org",
@@ -114,14 +115,14 @@ func TestComponentRouterHostSearch(t *testing.T) {
 
 		{
 			query: "x.y.com",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valAny",
 				Tail:  []string{"com", "y", "x"},
 			},
 		},
 		{
 			query: "x.y.org",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valAny",
 				Tail:  []string{"org", "y", "x"},
 			},
@@ -133,7 +134,7 @@ func TestComponentRouterHostSearch(t *testing.T) {
 			query    = searchTest.query
 			expected = searchTest.expected
 
-			visitor = new(search.VisitorFirst[string])
+			visitor = new(search.VisitorFirst[string, string])
 			info    = graphtest.Info(query, &tree)
 		)
 
@@ -183,17 +184,17 @@ func TestComponentRouterPathSearch(t *testing.T) {
 
 	searchTests := []struct {
 		query    Query
-		expected graph.SearchResult[string]
+		expected graph.SearchResult[string, string]
 	}{
 		{
 			query: "/foo/a/b",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valFoo1",
 			},
 		},
 		{
 			query: "/foo/a/x",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valFoo2",
 				Parameters: map[string]string{
 					"some": "x",
@@ -202,14 +203,14 @@ func TestComponentRouterPathSearch(t *testing.T) {
 		},
 		{
 			query: "/foo/a/x/y",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valFoo3",
 				Tail:  []string{"x", "y"},
 			},
 		},
 		{
 			query: "/bar/a/b",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valOther1",
 				Parameters: map[string]string{
 					"other": "bar",
@@ -218,7 +219,7 @@ func TestComponentRouterPathSearch(t *testing.T) {
 		},
 		{
 			query: "/bar/a/x",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valOther2",
 				Parameters: map[string]string{
 					"some":  "x",
@@ -228,7 +229,7 @@ func TestComponentRouterPathSearch(t *testing.T) {
 		},
 		{
 			query: "/bar/a/x/y",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valOther3",
 				Parameters: map[string]string{
 					"other": "bar",
@@ -238,14 +239,14 @@ func TestComponentRouterPathSearch(t *testing.T) {
 		},
 		{
 			query: "/foo/x/y",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valAny",
 				Tail:  []string{"foo", "x", "y"},
 			},
 		},
 		{
 			query: "/bar/x/y",
-			expected: graph.SearchResult[string]{
+			expected: graph.SearchResult[string, string]{
 				Value: "valAny",
 				Tail:  []string{"bar", "x", "y"},
 			},
@@ -257,7 +258,7 @@ func TestComponentRouterPathSearch(t *testing.T) {
 			query    = searchTest.query
 			expected = searchTest.expected
 
-			visitor = new(search.VisitorFirst[string])
+			visitor = new(search.VisitorFirst[string, string])
 			info    = graphtest.Info(query, &tree)
 		)
 
@@ -280,3 +281,260 @@ func TestComponentRouterPathSearch(t *testing.T) {
 		assert.Equal(t, expected.Tail, actual.Tail, info.Note("check tail"))
 	}
 }
+
+func TestComponentRouterAddAll(t *testing.T) {
+	var (
+		tree   Tree
+		router = component.NewPathRouter(tree.AsOption)
+	)
+
+	require.NoError(t, router.Add("/a", "valA"))
+
+	err := router.AddAll([]component.Route[string]{
+		{Pattern: "/b", Value: "valB"},
+		{Pattern: "noslash", Value: "valInvalid"},
+		{Pattern: "/a", Value: "valDuplicate"},
+		{Pattern: "/a/:", Value: "valEmptyParam"},
+	})
+
+	var batchErr graph.BatchError
+	require.ErrorAs(t, err, &batchErr, graphtest.Info(&tree))
+
+	var patterns []string
+	for _, item := range batchErr.Errors {
+		var routeErr component.RouteError
+		if assert.ErrorAs(t, item, &routeErr) {
+			patterns = append(patterns, routeErr.Pattern)
+		}
+	}
+
+	assert.Equal(t, []string{"noslash", "/a", "/a/:"}, patterns)
+	assert.ErrorIs(t, err, component.ErrInvalidPath)
+	assert.ErrorIs(t, err, component.ErrInvalidSegment)
+
+	visitor := new(search.VisitorFirst[string, string])
+	require.NoError(t, router.Search(visitor, "/b"))
+	assert.Nil(t, visitor.Result, graphtest.Info(&tree).Note("check rollback"))
+
+	require.NoError(t, router.AddAll([]component.Route[string]{
+		{Pattern: "/b", Value: "valB"},
+		{Pattern: "/c", Value: "valC"},
+	}))
+
+	require.NoError(t, router.Search(visitor, "/c"))
+	if assert.NotNil(t, visitor.Result, graphtest.Info(&tree)) {
+		assert.Equal(t, "valC", visitor.Result.Value)
+	}
+}
+
+func TestComponentRouterSearchContext(t *testing.T) {
+	var (
+		tree   Tree
+		router = component.NewPathRouter(tree.AsOption)
+	)
+
+	require.NoError(t, router.Add("/a/:some", "valA"))
+
+	visitor := new(search.VisitorFirst[string, string])
+	require.NoError(t, router.SearchContext(context.Background(), visitor, "/a/b"))
+	assert.NotNil(t, visitor.Result, graphtest.Info(&tree))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	visitor = new(search.VisitorFirst[string, string])
+	assert.ErrorIs(t, router.SearchContext(ctx, visitor, "/a/b"), context.Canceled)
+	assert.Nil(t, visitor.Result, graphtest.Info(&tree))
+
+	assert.ErrorIs(t, router.SearchContext(context.Background(), visitor, "noslash"), component.ErrInvalidPath)
+
+	visitor = new(search.VisitorFirst[string, string])
+	assert.ErrorIs(t, router.SearchBudget(context.Background(), 1, visitor, "/a/b"), graph.ErrSearchBudget)
+	assert.Nil(t, visitor.Result, graphtest.Info(&tree))
+
+	require.NoError(t, router.SearchBudget(context.Background(), 10, visitor, "/a/b"))
+	assert.NotNil(t, visitor.Result, graphtest.Info(&tree))
+}
+
+func TestComponentRouterAddGuarded(t *testing.T) {
+	var (
+		tree   Tree
+		router = component.NewPathRouter(tree.AsOption)
+
+		guardParam = func(_ context.Context, result *graph.SearchResult[string, string]) bool {
+			return result.Parameters["id"] != "forbidden"
+		}
+	)
+
+	require.NoError(t, router.AddGuarded("/users/:id", "valGuarded", guardParam))
+	require.NoError(t, router.Add("/users/*", "valFallback"))
+
+	for query, expected := range map[string]string{"/users/123": "valGuarded", "/users/forbidden": "valFallback"} {
+		visitor := new(search.VisitorFirst[string, string])
+		require.NoError(t, router.SearchContext(context.Background(), visitor, query))
+
+		if assert.NotNil(t, visitor.Result, graphtest.Info(Query(query), &tree)) {
+			assert.Equal(t, expected, visitor.Result.Value, graphtest.Info(Query(query), &tree))
+		}
+	}
+
+	unsupported := component.NewPathRouter(func(r *component.Router[string, string]) {
+		r.Tree = graph.Tree[string, string](nil)
+	})
+	assert.ErrorIs(t, unsupported.AddGuarded("/a", "valA", guardParam), component.ErrUnsupportedTree)
+}
+
+func TestComponentRouterIndexed(t *testing.T) {
+	var (
+		host = component.NewIndexedHostRouter[string]()
+		path = component.NewIndexedPathRouter[string]()
+	)
+
+	require.NoError(t, host.Add(":sub.:domain.com", "valHost"))
+	require.NoError(t, path.Add("/:first/a/:second", "valPath"))
+
+	hostVisitor := new(search.VisitorFirst[string, int])
+	require.NoError(t, host.Search(hostVisitor, "x.y.com"))
+
+	if assert.NotNil(t, hostVisitor.Result) {
+		assert.Equal(t, map[int]string{0: "y", 1: "x"}, hostVisitor.Result.Parameters)
+	}
+
+	pathVisitor := new(search.VisitorFirst[string, int])
+	require.NoError(t, path.Search(pathVisitor, "/x/a/y"))
+
+	if assert.NotNil(t, pathVisitor.Result) {
+		assert.Equal(t, map[int]string{0: "x", 1: "y"}, pathVisitor.Result.Parameters)
+	}
+
+	actual, err := path.Build("/:first/a/:second", map[int]string{0: "x", 1: "y"})
+	require.NoError(t, err)
+	assert.Equal(t, "/x/a/y", actual)
+}
+
+func TestComponentRouterDistinctTrailingSlash(t *testing.T) {
+	router := component.NewPathRouter(component.DistinctTrailingSlash[string, string])
+
+	require.NoError(t, router.Add("/a", "valA"))
+	require.NoError(t, router.Add("/a/", "valASlash"))
+	require.NoError(t, router.Add("/b/:id/", "valBSlash"))
+
+	for query, expected := range map[string]string{"/a": "valA", "/a/": "valASlash", "/b/1/": "valBSlash"} {
+		visitor := new(search.VisitorFirst[string, string])
+		require.NoError(t, router.Search(visitor, query))
+
+		if assert.NotNil(t, visitor.Result, query) {
+			assert.Equal(t, expected, visitor.Result.Value, query)
+		}
+	}
+
+	visitor := new(search.VisitorFirst[string, string])
+	require.NoError(t, router.Search(visitor, "/b/1"))
+	assert.Nil(t, visitor.Result)
+
+	built, err := router.Build("/b/:id/", map[string]string{"id": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, "/b/x/", built)
+
+	pattern, err := router.Pattern([]graph.Key{graph.KeyConstant("a"), graph.KeyConstant("")})
+	require.NoError(t, err)
+	assert.Equal(t, "/a/", pattern)
+
+	_, err = router.Build("/a//b", nil)
+	assert.ErrorIs(t, err, component.ErrInvalidSegment)
+}
+
+func TestComponentRouterDistinctTrailingSlashParameter(t *testing.T) {
+	router := component.NewPathRouter(component.DistinctTrailingSlash[string, string])
+	require.NoError(t, router.Add("/users/:id", "valUser"))
+
+	visitor := new(search.VisitorFirst[string, string])
+	require.NoError(t, router.Search(visitor, "/users/"))
+	assert.Nil(t, visitor.Result, "without /users/")
+
+	require.NoError(t, router.Add("/users/", "valUsers"))
+
+	for query, expected := range map[string]string{"/users/": "valUsers", "/users/1": "valUser"} {
+		visitor := new(search.VisitorAll[string, string])
+		require.NoError(t, router.Search(visitor, query))
+
+		if assert.Len(t, visitor.Results, 1, query) {
+			assert.Equal(t, expected, visitor.Results[0].Value, query)
+		}
+	}
+}
+
+func TestComponentRouterStrictHostBuild(t *testing.T) {
+	router := component.NewHostRouter(component.StrictHost[string, string])
+
+	for pattern, expected := range map[string]string{
+		"[::1]:8080":        "[::1]",
+		"127.0.0.1":         "127.0.0.1",
+		":sub.Example.com.": "x.example.com",
+	} {
+		actual, err := router.Build(pattern, map[string]string{"sub": "x"})
+		require.NoError(t, err, pattern)
+		assert.Equal(t, expected, actual, pattern)
+	}
+}
+
+func TestComponentRouterEscapedCleanPath(t *testing.T) {
+	for name, opts := range map[string][]func(*component.Router[string, string]){
+		"clean after escaped":  {component.EscapedPath[string, string], component.CleanPath[string, string]},
+		"clean before escaped": {component.CleanPath[string, string], component.EscapedPath[string, string]},
+		"trailing slash last":  {component.EscapedPath[string, string], component.CleanPath[string, string], component.DistinctTrailingSlash[string, string]},
+	} {
+		router := component.NewPathRouter(opts...)
+
+		require.NoError(t, router.Add("/secret", "valSecret"), name)
+		require.NoError(t, router.Add("/public/:f", "valPublic"), name)
+
+		for _, query := range []string{"/public/%2E%2E/secret", "/public/%2e%2e/secret", "/public/%2e/secret"} {
+			visitor := new(search.VisitorFirst[string, string])
+			require.NoError(t, router.Search(visitor, query), name)
+			assert.Nil(t, visitor.Result, "%s: %s", name, query)
+		}
+
+		for query, expected := range map[string]string{"/public/%2E%2E": "..", "/public/%2e": ".", "/public/x/../y": "y"} {
+			visitor := new(search.VisitorFirst[string, string])
+			require.NoError(t, router.Search(visitor, query), name)
+
+			if assert.NotNil(t, visitor.Result, "%s: %s", name, query) {
+				assert.Equal(t, map[string]string{"f": expected}, visitor.Result.Parameters, "%s: %s", name, query)
+			}
+		}
+	}
+}
+
+func TestComponentRouterOptionOrder(t *testing.T) {
+	var (
+		escaped  = component.EscapedPath[string, string]
+		clean    = component.CleanPath[string, string]
+		trailing = component.DistinctTrailingSlash[string, string]
+	)
+
+	for name, opts := range map[string][]func(*component.Router[string, string]){
+		"trailing first": {trailing, escaped, clean},
+		"trailing last":  {escaped, clean, trailing},
+		"trailing mid":   {clean, trailing, escaped},
+	} {
+		router := component.NewPathRouter(opts...)
+
+		require.NoError(t, router.Add("/files/:name", "valFile"), name)
+		require.NoError(t, router.Add("/files/", "valFiles"), name)
+
+		for query, expected := range map[string]string{
+			"/files/a%2Fb":    "valFile",
+			"/x/../files/a/.": "valFile",
+			"/files/":         "valFiles",
+			"/x/../files//":   "valFiles",
+		} {
+			visitor := new(search.VisitorFirst[string, string])
+			require.NoError(t, router.Search(visitor, query), "%s: %s", name, query)
+
+			if assert.NotNil(t, visitor.Result, "%s: %s", name, query) {
+				assert.Equal(t, expected, visitor.Result.Value, "%s: %s", name, query)
+			}
+		}
+	}
+}
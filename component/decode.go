@@ -35,6 +35,8 @@ var ErrInvalidSegment = errors.New("invalid segment")
 //
 // Doing such necessitates that KeyDecoder.Decode() can be stateful (scoped param counter),
 // thus []string -> []graph.Key rather than string -> graph.Key
+//
+// This is implemented by URLRouter.
 
 type KeyDecoder interface {
 	Decode([]string) ([]graph.Key, error)
@@ -61,6 +63,46 @@ func (kdf KeyDecodeFunc) Decode(segs []string) ([]graph.Key, error) {
 	return res, nil
 }
 
+// IndexKeyDecoder replaces the parameter names produced by Decoder with their
+// position within the decoded key path, for use by Router[V, int]. Host keys
+// are decoded right to left, so host positions count from the top level domain.
+type IndexKeyDecoder struct{ Decoder KeyDecoder }
+
+func (ikd IndexKeyDecoder) Decode(segs []string) ([]graph.Key, error) {
+	keys, err := ikd.Decoder.Decode(segs)
+	if err != nil {
+		return nil, err
+	}
+
+	var idx int
+	for i, key := range keys {
+		if _, ok := key.(graph.KeyParameter[string]); ok {
+			keys[i] = graph.KeyParameter[int]{Name: idx}
+			idx++
+		}
+	}
+
+	return keys, nil
+}
+
+// TrailingSlashDecoder decodes a final empty segment, as produced by
+// TrailingSlashPathSegmenter, into an empty constant key.
+type TrailingSlashDecoder struct{ Decoder KeyDecoder }
+
+func (tsd TrailingSlashDecoder) Decode(segs []string) ([]graph.Key, error) {
+	last := len(segs) - 1
+	if last < 0 || segs[last] != "" {
+		return tsd.Decoder.Decode(segs)
+	}
+
+	keys, err := tsd.Decoder.Decode(segs[:last])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keys, graph.KeyConstant("")), nil
+}
+
 func decodeKeyDefault(raw string) (graph.Key, error) {
 	rawLen := len(raw)
 	if rawLen == 0 {
@@ -73,7 +115,7 @@ func decodeKeyDefault(raw string) (graph.Key, error) {
 			return nil, fmt.Errorf("%w: empty parameter name", ErrInvalidSegment)
 		}
 
-		return graph.KeyParameter(raw[1:]), nil
+		return graph.KeyParameter[string]{Name: raw[1:]}, nil
 	case decPrefixWild:
 		return graph.KeyWildcard{}, nil
 	}
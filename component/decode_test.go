@@ -40,7 +40,7 @@ func TestComponentDecodeKeySuccess(t *testing.T) {
 		},
 		{
 			input:    ":someParam",
-			expected: graph.KeyParameter("someParam"),
+			expected: graph.KeyParameter[string]{Name: "someParam"},
 		},
 		{
 			input:    "*someWild",
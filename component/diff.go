@@ -0,0 +1,92 @@
+package component
+
+import (
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/diff"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+)
+
+type viewTree[V any, P comparable] interface {
+	View() *priority.ViewNode[V, P]
+}
+
+func (r *Router[V, P]) view() (*priority.ViewNode[V, P], error) {
+	if tree, ok := r.Tree.(viewTree[V, P]); ok {
+		return tree.View(), nil
+	}
+
+	return nil, ErrUnsupportedTree
+}
+
+func (r *Router[V, P]) first(query string) (*graph.SearchResult[V, P], error) {
+	visitor := new(search.VisitorFirst[V, P])
+	err := r.Search(visitor, query)
+	return visitor.Result, err
+}
+
+func (r *Router[V, P]) setPattern(route *diff.Route[V]) (err error) {
+	route.Pattern, err = r.Pattern(route.Path)
+	return
+}
+
+// NOTE: Each route is formatted by the router it was found in
+func setPatterns[V any, P comparable](report *diff.Report[V, P], before, after *Router[V, P]) error {
+	for i := range report.Removed {
+		if err := before.setPattern(&report.Removed[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range report.Added {
+		if err := after.setPattern(&report.Added[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range report.Changed {
+		if err := before.setPattern(&report.Changed[i].Old); err != nil {
+			return err
+		}
+
+		if err := after.setPattern(&report.Changed[i].New); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Diff[V any, P comparable](differ diff.Differ[V, P], before, after *Router[V, P], queries ...string) (diff.Report[V, P], error) {
+	beforeView, err := before.view()
+	if err != nil {
+		return diff.Report[V, P]{}, err
+	}
+
+	afterView, err := after.view()
+	if err != nil {
+		return diff.Report[V, P]{}, err
+	}
+
+	res := differ.Views(beforeView, afterView)
+
+	if err := setPatterns(&res, before, after); err != nil {
+		return res, err
+	}
+
+	for _, query := range queries {
+		beforeResult, err := before.first(query)
+		if err != nil {
+			return res, err
+		}
+
+		afterResult, err := after.first(query)
+		if err != nil {
+			return res, err
+		}
+
+		differ.Resolve(&res, query, beforeResult, afterResult)
+	}
+
+	return res, nil
+}
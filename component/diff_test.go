@@ -0,0 +1,64 @@
+package component_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/component"
+	"github.com/oligarch316/go-urlrouter/graph/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentDiff(t *testing.T) {
+	var (
+		before = component.NewPathRouter[string]()
+		after  = component.NewPathRouter[string]()
+	)
+
+	require.NoError(t, before.Add("/users/:id", "valUser"))
+	require.NoError(t, before.Add("/users/new", "valUserNew"))
+
+	require.NoError(t, after.Add("/users/:id", "valUser"))
+	require.NoError(t, after.Add("/users/*any", "valUserAny"))
+
+	report, err := component.Diff(diff.Differ[string, string]{}, before, after, "/users/new", "/users/x", "/users/x/y")
+	require.NoError(t, err)
+
+	if assert.Len(t, report.Added, 1) {
+		assert.Equal(t, "/users/*", report.Added[0].Pattern)
+		assert.Equal(t, "/users/*→value(valUserAny)", report.Added[0].String())
+	}
+
+	if assert.Len(t, report.Removed, 1) {
+		assert.Equal(t, "/users/new", report.Removed[0].Pattern)
+	}
+
+	assert.Empty(t, report.Changed)
+
+	if assert.Len(t, report.Resolutions, 2) {
+		assert.Equal(t, "/users/new", report.Resolutions[0].Query)
+		assert.Equal(t, "valUser", report.Resolutions[0].New.Value)
+		assert.Equal(t, "/users/x/y", report.Resolutions[1].Query)
+		assert.Nil(t, report.Resolutions[1].Old)
+	}
+}
+
+func TestComponentDiffChanged(t *testing.T) {
+	var (
+		before = component.NewPathRouter[string]()
+		after  = component.NewPathRouter[string]()
+	)
+
+	require.NoError(t, before.Add("/users/:id", "valUser"))
+	require.NoError(t, after.Add("/users/:uid", "valUser"))
+
+	report, err := component.Diff(diff.Differ[string, string]{}, before, after)
+	require.NoError(t, err)
+
+	if assert.Len(t, report.Changed, 1) {
+		assert.Equal(t, "/users/:id", report.Changed[0].Old.Pattern)
+		assert.Equal(t, "/users/:uid", report.Changed[0].New.Pattern)
+	}
+
+	assert.Equal(t, "~ /users/:id→value(valUser) => /users/:uid→value(valUser)", report.String())
+}
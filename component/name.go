@@ -0,0 +1,78 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+var (
+	ErrDuplicateName = errors.New("duplicate route name")
+	ErrUnknownName   = errors.New("unknown route name")
+)
+
+type NamedRoute struct {
+	Name    string
+	Pattern string
+	Keys    []graph.Key
+}
+
+func (r *Router[V, P]) checkName(name string) error {
+	if _, ok := r.names[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateName, name)
+	}
+
+	return nil
+}
+
+func (r *Router[V, P]) setName(name, pattern string, keys []graph.Key) {
+	if r.names == nil {
+		r.names = make(map[string]NamedRoute)
+	}
+
+	r.names[name] = NamedRoute{Name: name, Pattern: pattern, Keys: keys}
+}
+
+func (r *Router[V, P]) AddNamed(name, pattern string, value V) error {
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+
+	keys, err := r.keys(pattern)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Tree.Add(value, keys...); err != nil {
+		return err
+	}
+
+	r.setName(name, pattern, keys)
+	return nil
+}
+
+func (r *Router[V, P]) Named(name string) (NamedRoute, bool) {
+	route, ok := r.names[name]
+	return route, ok
+}
+
+func (r *Router[V, P]) NamedRoutes() []NamedRoute {
+	res := make([]NamedRoute, 0, len(r.names))
+	for _, route := range r.names {
+		res = append(res, route)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (r *Router[V, P]) URL(name string, params map[P]string, tail ...string) (string, error) {
+	route, ok := r.names[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownName, name)
+	}
+
+	return r.BuildKeys(route.Keys, params, tail...)
+}
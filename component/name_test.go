@@ -0,0 +1,64 @@
+package component_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/component"
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentNamedRoutes(t *testing.T) {
+	router := component.NewPathRouter[string]()
+
+	require.NoError(t, router.AddNamed("user.show", "/users/:id", "valUser"))
+	require.NoError(t, router.AddAll([]component.Route[string]{
+		{Name: "static", Pattern: "/static/*any", Value: "valStatic"},
+		{Pattern: "/unnamed", Value: "valUnnamed"},
+	}))
+
+	actual, err := router.URL("user.show", map[string]string{"id": "123"})
+	require.NoError(t, err)
+	assert.Equal(t, "/users/123", actual)
+
+	actual, err = router.URL("static", nil, "css", "main.css")
+	require.NoError(t, err)
+	assert.Equal(t, "/static/css/main.css", actual)
+
+	var names []string
+	for _, route := range router.NamedRoutes() {
+		names = append(names, route.Name)
+	}
+	assert.Equal(t, []string{"static", "user.show"}, names)
+
+	route, ok := router.Named("user.show")
+	if assert.True(t, ok) {
+		assert.Equal(t, "/users/:id", route.Pattern)
+		assert.Equal(t, []graph.Key{graph.KeyConstant("users"), graph.KeyParameter[string]{Name: "id"}}, route.Keys)
+	}
+}
+
+func TestComponentNamedRoutesError(t *testing.T) {
+	router := component.NewPathRouter[string]()
+
+	require.NoError(t, router.AddNamed("a", "/a", "valA"))
+
+	assert.ErrorIs(t, router.AddNamed("a", "/b", "valB"), component.ErrDuplicateName)
+	assert.ErrorAs(t, router.AddNamed("b", "/a", "valDuplicate"), new(graph.DuplicateValueError[string]))
+
+	_, ok := router.Named("b")
+	assert.False(t, ok, "failed additions must not register a name")
+
+	err := router.AddAll([]component.Route[string]{
+		{Name: "c", Pattern: "/c", Value: "valC"},
+		{Name: "c", Pattern: "/d", Value: "valD"},
+	})
+	assert.ErrorIs(t, err, component.ErrDuplicateName)
+
+	_, ok = router.Named("c")
+	assert.False(t, ok, "failed batches must not register names")
+
+	_, err = router.URL("unknown", nil)
+	assert.ErrorIs(t, err, component.ErrUnknownName)
+}
@@ -1,20 +1,65 @@
 package component
 
-import "github.com/oligarch316/go-urlrouter/graph"
+import (
+	"context"
+	"errors"
+	"fmt"
 
-type Router[V any] struct {
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+var ErrUnsupportedTree = errors.New("unsupported tree")
+
+type Route[V any] struct {
+	Name    string
+	Pattern string
+	Value   V
+}
+
+type RouteError struct {
+	Pattern string
+	Err     error
+}
+
+func (re RouteError) Error() string { return fmt.Sprintf("route '%s': %s", re.Pattern, re.Err) }
+func (re RouteError) Unwrap() error { return re.Err }
+
+type Router[V any, P comparable] struct {
 	Decoder   KeyDecoder
+	Formatter PatternJoiner
+	Joiner    PatternJoiner
 	Segmenter PatternSegmenter
-	Tree      graph.Tree[V]
+	Tree      graph.Tree[V, P]
+
+	// QuerySegmenter segments queries in place of Segmenter, if set
+	QuerySegmenter PatternSegmenter
+
+	// ValueChecker, if set, rejects parameter and tail values given to Build,
+	// as opposed to the constants of the pattern built
+	ValueChecker func(string) error
+
+	names map[string]NamedRoute
 }
 
-func (r *Router[V]) Add(pattern string, value V) error {
+func (r *Router[V, P]) keys(pattern string) ([]graph.Key, error) {
 	segs, err := r.Segmenter.Segment(pattern)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	keys, err := r.Decoder.Decode(segs)
+	return r.Decoder.Decode(segs)
+}
+
+func (r *Router[V, P]) segmentQuery(query string) ([]string, error) {
+	if r.QuerySegmenter != nil {
+		return r.QuerySegmenter.Segment(query)
+	}
+
+	return r.Segmenter.Segment(query)
+}
+
+func (r *Router[V, P]) Add(pattern string, value V) error {
+	keys, err := r.keys(pattern)
 	if err != nil {
 		return err
 	}
@@ -22,8 +67,91 @@ func (r *Router[V]) Add(pattern string, value V) error {
 	return r.Tree.Add(value, keys...)
 }
 
-func (r *Router[V]) Search(searcher graph.Searcher[V], query string) error {
-	segs, err := r.Segmenter.Segment(query)
+func (r *Router[V, P]) AddGuarded(pattern string, value V, guard graph.Guard[V, P]) error {
+	adder, ok := r.Tree.(graph.GuardedAdder[V, P])
+	if !ok {
+		return ErrUnsupportedTree
+	}
+
+	keys, err := r.keys(pattern)
+	if err != nil {
+		return err
+	}
+
+	return adder.AddGuarded(value, guard, keys...)
+}
+
+func (r *Router[V, P]) AddAll(routes []Route[V]) error {
+	batcher, ok := r.Tree.(graph.Batcher[V])
+	if !ok {
+		return ErrUnsupportedTree
+	}
+
+	var (
+		errs     []error
+		keyPaths = make([][]graph.Key, len(routes))
+		names    = make(map[string]struct{})
+	)
+
+	err := batcher.Batch(func(tx graph.Adder[V]) error {
+		for i, route := range routes {
+			var err error
+
+			if route.Name != "" {
+				if err = r.checkName(route.Name); err == nil {
+					if _, ok := names[route.Name]; ok {
+						err = fmt.Errorf("%w: %s", ErrDuplicateName, route.Name)
+					}
+				}
+
+				names[route.Name] = struct{}{}
+			}
+
+			if err == nil {
+				keyPaths[i], err = r.keys(route.Pattern)
+			}
+
+			if err == nil {
+				err = tx.Add(route.Value, keyPaths[i]...)
+			}
+
+			// NOTE: Errors are already reported per route
+			var addErr graph.BatchAddError
+			if errors.As(err, &addErr) {
+				err = addErr.Err
+			}
+
+			if err != nil {
+				errs = append(errs, RouteError{Pattern: route.Pattern, Err: err})
+			}
+		}
+
+		if len(errs) > 0 {
+			return graph.BatchError{Errors: errs}
+		}
+
+		return nil
+	})
+
+	if len(errs) > 0 {
+		return graph.BatchError{Errors: errs}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for i, route := range routes {
+		if route.Name != "" {
+			r.setName(route.Name, route.Pattern, keyPaths[i])
+		}
+	}
+
+	return nil
+}
+
+func (r *Router[V, P]) Search(searcher graph.Searcher[V, P], query string) error {
+	segs, err := r.segmentQuery(query)
 	if err != nil {
 		return err
 	}
@@ -32,9 +160,45 @@ func (r *Router[V]) Search(searcher graph.Searcher[V], query string) error {
 	return nil
 }
 
-func (r *Router[V]) SearchFunc(searcher func(result *graph.SearchResult[V]) (done bool), query string) error {
-	return r.Search(graph.SearcherFunc[V](searcher), query)
+func (r *Router[V, P]) SearchContext(ctx context.Context, searcher graph.Searcher[V, P], query string) error {
+	segs, err := r.segmentQuery(query)
+	if err != nil {
+		return err
+	}
+
+	if tree, ok := r.Tree.(graph.ContextTree[V, P]); ok {
+		return tree.SearchContext(ctx, searcher, segs...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Tree.Search(searcher, segs...)
+	return nil
 }
 
-func (r *Router[V]) Walk(walker graph.Walker[V])               { r.Tree.Walk(walker) }
-func (r *Router[V]) WalkFunc(walker func(value V) (done bool)) { r.Walk(graph.WalkerFunc[V](walker)) }
+// SearchBudget is SearchContext bounded to steps nodes visited, failing with
+// ErrUnsupportedTree if the tree cannot enforce a budget.
+func (r *Router[V, P]) SearchBudget(ctx context.Context, steps int, searcher graph.Searcher[V, P], query string) error {
+	tree, ok := r.Tree.(graph.ContextTree[V, P])
+	if !ok {
+		return ErrUnsupportedTree
+	}
+
+	segs, err := r.segmentQuery(query)
+	if err != nil {
+		return err
+	}
+
+	return tree.SearchBudget(ctx, steps, searcher, segs...)
+}
+
+func (r *Router[V, P]) SearchFunc(searcher func(result *graph.SearchResult[V, P]) (done bool), query string) error {
+	return r.Search(graph.SearcherFunc[V, P](searcher), query)
+}
+
+func (r *Router[V, P]) Walk(walker graph.Walker[V]) { r.Tree.Walk(walker) }
+func (r *Router[V, P]) WalkFunc(walker func(value V) (done bool)) {
+	r.Walk(graph.WalkerFunc[V](walker))
+}
@@ -3,12 +3,17 @@ package component
 import (
 	"errors"
 	"fmt"
+	"net/netip"
+	"net/url"
 	"strings"
 )
 
 const (
 	segHostSep = '.'
 	segPathSep = '/'
+	segPortSep = ':'
+
+	segMaxLabelLen = 63
 )
 
 var (
@@ -42,6 +47,135 @@ func segmentHostDefault(pattern string) ([]string, error) {
 	return res, nil
 }
 
+func checkHostLabel(label string) error {
+	if label == "" || len(label) > segMaxLabelLen || label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("%w: invalid label '%s'", ErrInvalidHost, label)
+	}
+
+	for i := 0; i < len(label); i++ {
+		switch c := label[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_':
+		default:
+			return fmt.Errorf("%w: invalid label '%s'", ErrInvalidHost, label)
+		}
+	}
+
+	return nil
+}
+
+func segmentHostLabel(label string) (string, error) {
+	if err := checkHostLabel(label); err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(label), nil
+}
+
+// NOTE: Parameter and wildcard labels are passed through as is in patterns only
+func segmentHostPatternLabel(label string) (string, error) {
+	if (label != "" && label[0] == decPrefixWild) || (len(label) > 1 && label[0] == decPrefixParam) {
+		return label, nil
+	}
+
+	return segmentHostLabel(label)
+}
+
+func isPort(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NOTE: A leading colon begins a parameter label rather than a port
+func stripHostPort(pattern string) string {
+	if i := strings.LastIndexByte(pattern, segPortSep); i > 0 && isPort(pattern[i+1:]) {
+		return pattern[:i]
+	}
+
+	return pattern
+}
+
+func segmentHostIPv6(pattern string) ([]string, error) {
+	end := strings.IndexByte(pattern, ']')
+	if end < 0 {
+		return nil, fmt.Errorf("%w: unterminated IPv6 literal", ErrInvalidHost)
+	}
+
+	addr, err := netip.ParseAddr(pattern[1:end])
+	if err != nil || !addr.Is6() {
+		return nil, fmt.Errorf("%w: invalid IPv6 literal '%s'", ErrInvalidHost, pattern[1:end])
+	}
+
+	if rest := pattern[end+1:]; rest != "" && (rest[0] != segPortSep || !isPort(rest[1:])) {
+		return nil, fmt.Errorf("%w: invalid port '%s'", ErrInvalidHost, rest)
+	}
+
+	return []string{ipSegment(addr)}, nil
+}
+
+// NOTE: IPv6 segments are bracketed, lest a leading "::" decode as a parameter
+func ipSegment(addr netip.Addr) string {
+	if addr.Is6() {
+		return "[" + addr.String() + "]"
+	}
+
+	return addr.String()
+}
+
+// segmentHostStrict strips any port, lowercases and validates labels, ignores a
+// trailing root dot and keeps IP literals as single segments, in canonical form.
+func segmentHostStrict(pattern string) ([]string, error) {
+	return segmentHostStrictLabels(pattern, segmentHostPatternLabel)
+}
+
+// segmentHostStrictQuery is segmentHostStrict without parameter or wildcard
+// labels, as in "*.example.com", which are invalid in a query.
+func segmentHostStrictQuery(query string) ([]string, error) {
+	return segmentHostStrictLabels(query, segmentHostLabel)
+}
+
+func segmentHostStrictLabels(pattern string, segmentLabel func(string) (string, error)) ([]string, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	if pattern[0] == '[' {
+		return segmentHostIPv6(pattern)
+	}
+
+	// NOTE: Checked before ports are stripped, as in "::1"
+	if addr, err := netip.ParseAddr(pattern); err == nil {
+		return []string{ipSegment(addr)}, nil
+	}
+
+	pattern = strings.TrimSuffix(stripHostPort(pattern), string(segHostSep))
+
+	if addr, err := netip.ParseAddr(pattern); err == nil && addr.Is4() {
+		return []string{ipSegment(addr)}, nil
+	}
+
+	var (
+		fields  = strings.Split(pattern, string(segHostSep))
+		nFields = len(fields)
+		res     = make([]string, nFields)
+	)
+
+	for i, field := range fields {
+		label, err := segmentLabel(field)
+		if err != nil {
+			return nil, err
+		}
+
+		res[(nFields-1)-i] = label
+	}
+
+	return res, nil
+}
+
 func segmentPathDefault(pattern string) ([]string, error) {
 	if pattern == "" || pattern[0] != segPathSep {
 		return nil, fmt.Errorf("%w: missing leading slash", ErrInvalidPath)
@@ -63,3 +197,79 @@ func segmentPathDefault(pattern string) ([]string, error) {
 
 	return strings.Split(pattern, string(segPathSep)), nil
 }
+
+// NOTE: A trailing slash is kept as a final empty segment, so that "/a" and "/a/"
+// are distinct.
+func segmentPathTrailing(pattern string) ([]string, error) {
+	if pattern == "" || pattern[0] != segPathSep {
+		return nil, fmt.Errorf("%w: missing leading slash", ErrInvalidPath)
+	}
+
+	if pattern = pattern[1:]; pattern == "" {
+		return nil, nil
+	}
+
+	return strings.Split(pattern, string(segPathSep)), nil
+}
+
+// CleanPathSegmenter resolves "." and ".." segments and drops empty ones from
+// the output of Segmenter, such that "/a//b/../c" segments as "/a/c". A final
+// empty segment, as produced by TrailingSlashPathSegmenter, is kept. Wrap it
+// with an EscapedPathSegmenter rather than the reverse, lest escaped dots be
+// resolved.
+type CleanPathSegmenter struct{ Segmenter PatternSegmenter }
+
+func (cps CleanPathSegmenter) Segment(pattern string) ([]string, error) {
+	segs, err := cps.Segmenter.Segment(pattern)
+	if err != nil || len(segs) == 0 {
+		return segs, err
+	}
+
+	var (
+		trailing = segs[len(segs)-1] == ""
+		res      = segs[:0]
+	)
+
+	for _, seg := range segs {
+		switch seg {
+		case "", ".":
+		case "..":
+			// NOTE: ".." above the root is dropped, as with path.Clean
+			if len(res) > 0 {
+				res = res[:len(res)-1]
+			}
+		default:
+			res = append(res, seg)
+		}
+	}
+
+	if trailing && len(res) > 0 {
+		res = append(res, "")
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+// EscapedPathSegmenter segments an escaped path, as from url.URL.EscapedPath,
+// with Segmenter and then unescapes each segment. An encoded "%2F" is thus part
+// of a segment rather than a separator.
+type EscapedPathSegmenter struct{ Segmenter PatternSegmenter }
+
+func (eps EscapedPathSegmenter) Segment(pattern string) ([]string, error) {
+	segs, err := eps.Segmenter.Segment(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, seg := range segs {
+		if segs[i], err = url.PathUnescape(seg); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPath, err)
+		}
+	}
+
+	return segs, nil
+}
@@ -2,6 +2,7 @@ package component_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/oligarch316/go-urlrouter/component"
@@ -115,3 +116,202 @@ func TestComponentSegmentPathSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentSegmentPathTrailingSuccess(t *testing.T) {
+	subtests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "/",
+			expected: nil,
+		},
+		{
+			input:    "/a",
+			expected: []string{"a"},
+		},
+		{
+			input:    "/a/",
+			expected: []string{"a", ""},
+		},
+		{
+			input:    "/a/b/c/",
+			expected: []string{"a", "b", "c", ""},
+		},
+	}
+
+	for _, subtest := range subtests {
+		var (
+			st   = subtest
+			name = fmt.Sprintf("input '%s'", st.input)
+		)
+
+		t.Run(name, func(t *testing.T) {
+			actual, err := component.TrailingSlashPathSegmenter(st.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, st.expected, actual)
+		})
+	}
+}
+
+func TestComponentSegmentPathCleanSuccess(t *testing.T) {
+	subtests := []struct {
+		segmenter component.PatternSegmenter
+		input     string
+		expected  []string
+	}{
+		{segmenter: component.DefaultPathSegmenter, input: "/", expected: nil},
+		{segmenter: component.DefaultPathSegmenter, input: "//", expected: nil},
+		{segmenter: component.DefaultPathSegmenter, input: "/a//b", expected: []string{"a", "b"}},
+		{segmenter: component.DefaultPathSegmenter, input: "/a/./b/", expected: []string{"a", "b"}},
+		{segmenter: component.DefaultPathSegmenter, input: "/a/b/../c", expected: []string{"a", "c"}},
+		{segmenter: component.DefaultPathSegmenter, input: "/../../a", expected: []string{"a"}},
+		{segmenter: component.DefaultPathSegmenter, input: "/a/..", expected: nil},
+		{segmenter: component.TrailingSlashPathSegmenter, input: "/a//", expected: []string{"a", ""}},
+		{segmenter: component.TrailingSlashPathSegmenter, input: "/a/./b/../", expected: []string{"a", ""}},
+		{segmenter: component.TrailingSlashPathSegmenter, input: "/a/b/..", expected: []string{"a"}},
+		{segmenter: component.TrailingSlashPathSegmenter, input: "/a/../", expected: nil},
+	}
+
+	for _, subtest := range subtests {
+		var (
+			st   = subtest
+			name = fmt.Sprintf("input '%s'", st.input)
+		)
+
+		t.Run(name, func(t *testing.T) {
+			actual, err := component.CleanPathSegmenter{Segmenter: st.segmenter}.Segment(st.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, st.expected, actual)
+		})
+	}
+}
+
+func TestComponentSegmentPathEscapedSuccess(t *testing.T) {
+	subtests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "/", expected: nil},
+		{input: "/a/b", expected: []string{"a", "b"}},
+		{input: "/files/a%2Fb", expected: []string{"files", "a/b"}},
+		{input: "/a%20b/:id/", expected: []string{"a b", ":id"}},
+	}
+
+	for _, subtest := range subtests {
+		var (
+			st   = subtest
+			name = fmt.Sprintf("input '%s'", st.input)
+		)
+
+		t.Run(name, func(t *testing.T) {
+			segmenter := component.EscapedPathSegmenter{Segmenter: component.DefaultPathSegmenter}
+			actual, err := segmenter.Segment(st.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, st.expected, actual)
+		})
+	}
+}
+
+func TestComponentSegmentPathEscapedError(t *testing.T) {
+	segmenter := component.EscapedPathSegmenter{Segmenter: component.DefaultPathSegmenter}
+
+	for _, input := range []string{"noslash", "/a/%zz"} {
+		in := input
+
+		t.Run(fmt.Sprintf("input '%s'", in), func(t *testing.T) {
+			_, err := segmenter.Segment(in)
+			assert.ErrorIs(t, err, component.ErrInvalidPath)
+		})
+	}
+}
+
+func TestComponentSegmentHostStrictSuccess(t *testing.T) {
+	subtests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "", expected: nil},
+		{input: "Sub.Example.COM", expected: []string{"com", "example", "sub"}},
+		{input: "example.com.", expected: []string{"com", "example"}},
+		{input: "example.com:8080", expected: []string{"com", "example"}},
+		{input: "example.com.:8080", expected: []string{"com", "example"}},
+		{input: ":sub.example.com", expected: []string{"com", "example", ":sub"}},
+		{input: ":Sub.*:443", expected: []string{"*", ":Sub"}},
+		{input: "*sub.example.com", expected: []string{"com", "example", "*sub"}},
+		{input: "127.0.0.1", expected: []string{"127.0.0.1"}},
+		{input: "127.0.0.1:8080", expected: []string{"127.0.0.1"}},
+		{input: "::1", expected: []string{"[::1]"}},
+		{input: "[::1]", expected: []string{"[::1]"}},
+		{input: "[::1]:8080", expected: []string{"[::1]"}},
+		{input: "[2001:DB8::0001]", expected: []string{"[2001:db8::1]"}},
+	}
+
+	for _, subtest := range subtests {
+		var (
+			st   = subtest
+			name = fmt.Sprintf("input '%s'", st.input)
+		)
+
+		t.Run(name, func(t *testing.T) {
+			actual, err := component.StrictHostSegmenter(st.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, st.expected, actual)
+		})
+	}
+}
+
+func TestComponentSegmentHostStrictError(t *testing.T) {
+	inputs := []string{
+		".",
+		"a..b",
+		".example.com",
+		"-a.com",
+		"a-.com",
+		"a b.com",
+		"café.com",
+		"example.com:port",
+		"[::1",
+		"[127.0.0.1]",
+		"[::1]8080",
+		"[::1]:port",
+		strings.Repeat("a", 64) + ".com",
+	}
+
+	for _, input := range inputs {
+		var (
+			in   = input
+			name = fmt.Sprintf("input '%s'", in)
+		)
+
+		t.Run(name, func(t *testing.T) {
+			_, err := component.StrictHostSegmenter(in)
+			assert.ErrorIs(t, err, component.ErrInvalidHost)
+		})
+	}
+}
+
+func TestComponentSegmentHostStrictQueryError(t *testing.T) {
+	inputs := []string{
+		"*.example.com",
+		"*sub.example.com",
+		":x.example.com",
+		"a..b",
+	}
+
+	for _, input := range inputs {
+		var (
+			in   = input
+			name = fmt.Sprintf("input '%s'", in)
+		)
+
+		t.Run(name, func(t *testing.T) {
+			_, err := component.StrictHostQuerySegmenter(in)
+			assert.ErrorIs(t, err, component.ErrInvalidHost)
+		})
+	}
+}
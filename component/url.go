@@ -0,0 +1,265 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+)
+
+var ErrParameterCollision = errors.New("parameter collision")
+
+// NOTE: Host patterns are routed by parameter position, so patterns differing
+// only in parameter names share a host entry and thus a path table. Each path
+// value records the host parameter names of the pattern it was added under.
+// See KeyDecoder for the original plan.
+
+type urlValue[V any] struct {
+	hostNames    []string
+	hostTailName string
+	value        V
+}
+
+type urlHost[V any] struct {
+	paths priority.Tree[urlValue[V], string]
+}
+
+// NOTE: The paths router only segments and decodes patterns, each host entry
+// holds its own path tree.
+type URLRouter[V any] struct {
+	// Coexist, when set, allows several values per host and path pattern pair.
+	// See priority.Tree.Coexist
+	Coexist func(existing, value V) bool
+
+	hosts *Router[*urlHost[V], int]
+	paths *Router[urlValue[V], string]
+
+	escapedPath bool
+}
+
+func NewURLRouter[V any](opts ...func(*URLRouter[V])) *URLRouter[V] {
+	res := &URLRouter[V]{
+		hosts: NewIndexedHostRouter[*urlHost[V]](),
+		paths: NewPathRouter[urlValue[V]](),
+	}
+
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
+}
+
+// URLStrictHost applies StrictHost to the host patterns and queries of a
+// URLRouter.
+func URLStrictHost[V any](ur *URLRouter[V]) { StrictHost(ur.hosts) }
+
+// URLDistinctTrailingSlash applies DistinctTrailingSlash to the path patterns and
+// queries of a URLRouter.
+func URLDistinctTrailingSlash[V any](ur *URLRouter[V]) { DistinctTrailingSlash(ur.paths) }
+
+// URLEscapedPath applies EscapedPath to the path patterns and queries of a
+// URLRouter.
+func URLEscapedPath[V any](ur *URLRouter[V]) {
+	EscapedPath(ur.paths)
+	ur.escapedPath = true
+}
+
+// EscapedPath reports whether path queries are expected in escaped form, as
+// with URLEscapedPath.
+func (ur *URLRouter[V]) EscapedPath() bool { return ur.escapedPath }
+
+// URLPath returns the path of u as queried by SearchURL, escaped if
+// EscapedPath and "/" if empty.
+func (ur *URLRouter[V]) URLPath(u *url.URL) string {
+	path := u.Path
+	if ur.escapedPath {
+		path = u.EscapedPath()
+	}
+
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
+
+// URLCleanPath applies CleanPath to the path patterns and queries of a URLRouter.
+func URLCleanPath[V any](ur *URLRouter[V]) { CleanPath(ur.paths) }
+
+func parameterNames(keys []graph.Key) []string {
+	var res []string
+	for _, key := range keys {
+		if param, ok := key.(graph.KeyParameter[string]); ok {
+			res = append(res, param.Name)
+		}
+	}
+	return res
+}
+
+func (ur *URLRouter[V]) hostKeys(pattern string) (indexed []graph.Key, names []string, tailName string, err error) {
+	segs, err := ur.hosts.Segmenter.Segment(pattern)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	named, err := DefaultKeyDecoder.Decode(segs)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	names = parameterNames(named)
+
+	// NOTE: A named host wildcard, as in "*sub.example.com", records the labels
+	// it matches as a parameter. Being the leftmost label, it is the final segment
+	if n := len(segs); n > 0 && len(segs[n-1]) > 1 && segs[n-1][0] == decPrefixWild {
+		tailName = segs[n-1][1:]
+		names = append(names, tailName)
+	}
+
+	indexed, err = IndexKeyDecoder{Decoder: DefaultKeyDecoder}.Decode(segs)
+	return indexed, names, tailName, err
+}
+
+func (ur *URLRouter[V]) newHost() *urlHost[V] {
+	res := new(urlHost[V])
+
+	if coexist := ur.Coexist; coexist != nil {
+		res.paths.Coexist = func(existing, value urlValue[V]) bool { return coexist(existing.value, value.value) }
+	}
+
+	return res
+}
+
+func unwrapPathError[V any](err error) error {
+	var dupErr graph.DuplicateValueError[urlValue[V]]
+	if errors.As(err, &dupErr) {
+		return graph.DuplicateValueError[V]{ExistingValue: dupErr.ExistingValue.value}
+	}
+
+	return err
+}
+
+// NOTE: The path is added to a fresh host entry before that entry is added, so
+// that a failure leaves no empty entry behind. Should an entry for the host
+// pattern exist already, the path is added to it instead.
+func (ur *URLRouter[V]) Add(hostPattern, pathPattern string, value V) error {
+	hostKeys, hostNames, hostTailName, err := ur.hostKeys(hostPattern)
+	if err != nil {
+		return err
+	}
+
+	pathKeys, err := ur.paths.keys(pathPattern)
+	if err != nil {
+		return err
+	}
+
+	for _, pathName := range parameterNames(pathKeys) {
+		for _, hostName := range hostNames {
+			if pathName == hostName {
+				return fmt.Errorf("%w: '%s' in both host and path", ErrParameterCollision, pathName)
+			}
+		}
+	}
+
+	var (
+		entry     = ur.newHost()
+		pathValue = urlValue[V]{hostNames: hostNames, hostTailName: hostTailName, value: value}
+	)
+
+	if err := entry.paths.Add(pathValue, pathKeys...); err != nil {
+		return unwrapPathError[V](err)
+	}
+
+	err = ur.hosts.Tree.Add(entry, hostKeys...)
+
+	var dupErr graph.DuplicateValueError[*urlHost[V]]
+	if errors.As(err, &dupErr) {
+		err = dupErr.ExistingValue.paths.Add(pathValue, pathKeys...)
+	}
+
+	return unwrapPathError[V](err)
+}
+
+func mergeResults[V any](host *graph.SearchResult[*urlHost[V], int], path *graph.SearchResult[urlValue[V], string], rank int) *graph.SearchResult[V, string] {
+	var (
+		hostNames = path.Value.hostNames
+		nParams   = len(hostNames) + len(path.ParameterNames)
+	)
+
+	res := &graph.SearchResult[V, string]{
+		Path:  path.Path,
+		Rank:  rank,
+		Tail:  path.Tail,
+		Value: path.Value.value,
+	}
+
+	if nParams > 0 {
+		res.Parameters = make(map[string]string, nParams)
+		res.ParameterNames = append(append(make([]string, 0, nParams), hostNames...), path.ParameterNames...)
+		res.ParameterValues = append(make([]string, 0, nParams), host.ParameterValues...)
+
+		// NOTE: Host segments are reversed, so the tail is formatted back into
+		// label order
+		if path.Value.hostTailName != "" {
+			tail, _ := formatHostDefault(host.Tail)
+			res.ParameterValues = append(res.ParameterValues, tail)
+		}
+
+		res.ParameterValues = append(res.ParameterValues, path.ParameterValues...)
+
+		for i, name := range res.ParameterNames {
+			res.Parameters[name] = res.ParameterValues[i]
+		}
+	}
+
+	return res
+}
+
+// Search visits every path match within every matching host, in host priority
+// order followed by path priority order.
+func (ur *URLRouter[V]) Search(searcher graph.Searcher[V, string], host, path string) error {
+	return ur.SearchHostFunc(func(_ int, result *graph.SearchResult[V, string]) bool {
+		return searcher.VisitSearch(result)
+	}, host, path)
+}
+
+// SearchHostFunc is Search, also given the rank of the host match each result
+// was found under. Results sharing both host rank and Path were added with the
+// same host and path patterns.
+func (ur *URLRouter[V]) SearchHostFunc(searcher func(hostRank int, result *graph.SearchResult[V, string]) (done bool), host, path string) error {
+	pathSegs, err := ur.paths.segmentQuery(path)
+	if err != nil {
+		return err
+	}
+
+	var (
+		rank int
+		done bool
+	)
+
+	visitPath := func(hostResult *graph.SearchResult[*urlHost[V], int]) graph.Searcher[urlValue[V], string] {
+		return graph.SearcherFunc[urlValue[V], string](func(pathResult *graph.SearchResult[urlValue[V], string]) bool {
+			done = searcher(hostResult.Rank, mergeResults(hostResult, pathResult, rank))
+			rank++
+			return done
+		})
+	}
+
+	visitHost := func(hostResult *graph.SearchResult[*urlHost[V], int]) bool {
+		hostResult.Value.paths.Search(visitPath(hostResult), pathSegs...)
+		return done
+	}
+
+	return ur.hosts.SearchFunc(visitHost, host)
+}
+
+func (ur *URLRouter[V]) SearchFunc(searcher func(result *graph.SearchResult[V, string]) (done bool), host, path string) error {
+	return ur.Search(graph.SearcherFunc[V, string](searcher), host, path)
+}
+
+func (ur *URLRouter[V]) SearchURL(searcher graph.Searcher[V, string], u *url.URL) error {
+	return ur.Search(searcher, u.Hostname(), ur.URLPath(u))
+}
@@ -0,0 +1,29 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentURLRouterAddFailure(t *testing.T) {
+	var (
+		router = NewURLRouter[string]()
+		hosts  int
+	)
+
+	// Segments and decodes, but a wildcard cannot be continued
+	err := router.Add("a.example.com", "/*/b", "valInvalid")
+	assert.ErrorAs(t, err, new(graph.InvalidContinuationError))
+
+	router.hosts.WalkFunc(func(*urlHost[string]) bool { hosts++; return false })
+	assert.Zero(t, hosts, "check no host entry")
+
+	require.NoError(t, router.Add("a.example.com", "/a", "valA"))
+	assert.ErrorAs(t, router.Add("a.example.com", "/a", "valDuplicate"), new(graph.DuplicateValueError[string]))
+
+	router.hosts.WalkFunc(func(*urlHost[string]) bool { hosts++; return false })
+	assert.Equal(t, 1, hosts, "check shared host entry")
+}
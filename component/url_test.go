@@ -0,0 +1,193 @@
+package component_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/component"
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentURLRouter(t *testing.T) {
+	router := component.NewURLRouter[string]()
+
+	require.NoError(t, router.Add(":sub.example.com", "/users/:id", "valSubUser"))
+	require.NoError(t, router.Add(":tenant.example.com", "/orgs/:org", "valTenantOrg"))
+	require.NoError(t, router.Add("api.example.com", "/users/:id", "valAPIUser"))
+	require.NoError(t, router.Add("*.com", "/*", "valFallback"))
+
+	subtests := []struct {
+		name       string
+		host, path string
+		expected   []string
+		parameters map[string]string
+	}{
+		{
+			name:       "constant host",
+			host:       "api.example.com",
+			path:       "/users/123",
+			expected:   []string{"valAPIUser", "valSubUser", "valFallback"},
+			parameters: map[string]string{"id": "123"},
+		},
+		{
+			name:       "shared host entry first name",
+			host:       "x.example.com",
+			path:       "/users/123",
+			expected:   []string{"valSubUser", "valFallback"},
+			parameters: map[string]string{"sub": "x", "id": "123"},
+		},
+		{
+			name:       "shared host entry second name",
+			host:       "x.example.com",
+			path:       "/orgs/acme",
+			expected:   []string{"valTenantOrg", "valFallback"},
+			parameters: map[string]string{"tenant": "x", "org": "acme"},
+		},
+		{
+			name:     "fallback",
+			host:     "x.other.com",
+			path:     "/users/123",
+			expected: []string{"valFallback"},
+		},
+		{
+			name: "no match",
+			host: "example.org",
+			path: "/users/123",
+		},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			visitor := new(search.VisitorAll[string, string])
+			require.NoError(t, router.Search(visitor, st.host, st.path))
+
+			var actual []string
+			for i, result := range visitor.Results {
+				actual = append(actual, result.Value)
+				assert.Equal(t, i, result.Rank, "check rank %d", i)
+			}
+
+			assert.Equal(t, st.expected, actual)
+
+			if len(visitor.Results) > 0 && st.parameters != nil {
+				assert.Equal(t, st.parameters, visitor.Results[0].Parameters)
+			}
+		})
+	}
+
+	t.Run("url", func(t *testing.T) {
+		u, err := url.Parse("https://x.example.com:8443/orgs/acme?q=1")
+		require.NoError(t, err)
+
+		visitor := new(search.VisitorFirst[string, string])
+		require.NoError(t, router.SearchURL(visitor, u))
+
+		if assert.NotNil(t, visitor.Result) {
+			assert.Equal(t, "valTenantOrg", visitor.Result.Value)
+			assert.Equal(t, []string{"tenant", "org"}, visitor.Result.ParameterNames)
+			assert.Equal(t, []string{"x", "acme"}, visitor.Result.ParameterValues)
+		}
+	})
+}
+
+func TestComponentURLRouterHostTail(t *testing.T) {
+	router := component.NewURLRouter[string]()
+
+	require.NoError(t, router.Add("*sub.example.com", "/users/:id", "valUser"))
+	require.NoError(t, router.Add("*.example.com", "/items", "valItems"))
+
+	visitor := new(search.VisitorFirst[string, string])
+	require.NoError(t, router.Search(visitor, "a.b.example.com", "/users/1"))
+
+	if assert.NotNil(t, visitor.Result) {
+		assert.Equal(t, []string{"sub", "id"}, visitor.Result.ParameterNames)
+		assert.Equal(t, []string{"a.b", "1"}, visitor.Result.ParameterValues)
+		assert.Equal(t, map[string]string{"sub": "a.b", "id": "1"}, visitor.Result.Parameters)
+	}
+
+	visitor = new(search.VisitorFirst[string, string])
+	require.NoError(t, router.Search(visitor, "a.example.com", "/items"))
+
+	if assert.NotNil(t, visitor.Result) {
+		assert.Nil(t, visitor.Result.Parameters, "check unnamed")
+	}
+
+	assert.ErrorIs(t, router.Add("*id.example.org", "/:id", "valCollision"), component.ErrParameterCollision)
+}
+
+func TestComponentURLRouterError(t *testing.T) {
+	router := component.NewURLRouter[string]()
+	require.NoError(t, router.Add(":sub.example.com", "/users/:id", "valSubUser"))
+
+	t.Run("collision", func(t *testing.T) {
+		err := router.Add(":id.example.com", "/items/:id", "valItem")
+		assert.ErrorIs(t, err, component.ErrParameterCollision)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		var targetErr graph.DuplicateValueError[string]
+
+		err := router.Add(":other.example.com", "/users/:uid", "valOther")
+		if assert.ErrorAs(t, err, &targetErr) {
+			assert.Equal(t, "valSubUser", targetErr.ExistingValue)
+		}
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		assert.ErrorIs(t, router.Add("example.com", "noslash", "valA"), component.ErrInvalidPath)
+		assert.ErrorIs(t, router.Search(new(search.VisitorAll[string, string]), "example.com", "noslash"), component.ErrInvalidPath)
+	})
+}
+
+func TestComponentURLRouterEscapedPath(t *testing.T) {
+	router := component.NewURLRouter(component.URLEscapedPath[string])
+	require.NoError(t, router.Add("example.com", "/files/:name", "valFile"))
+
+	for raw, expected := range map[string]string{
+		"http://example.com/files/a%2Fb":  "a/b",
+		"http://example.com/files/100%25": "100%",
+	} {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+
+		visitor := new(search.VisitorFirst[string, string])
+		require.NoError(t, router.SearchURL(visitor, u), raw)
+
+		if assert.NotNil(t, visitor.Result, raw) {
+			assert.Equal(t, expected, visitor.Result.Parameters["name"], raw)
+		}
+	}
+}
+
+func TestComponentURLRouterStrictHost(t *testing.T) {
+	router := component.NewURLRouter(component.URLStrictHost[string])
+
+	require.NoError(t, router.Add(":sub.Example.com", "/", "valSub"))
+	require.NoError(t, router.Add("[::1]", "/", "valLoopback6"))
+	require.NoError(t, router.Add("127.0.0.1", "/", "valLoopback4"))
+
+	for host, expected := range map[string]string{
+		"x.EXAMPLE.com.:8080": "valSub",
+		"[0:0::1]:8080":       "valLoopback6",
+		"127.0.0.1:80":        "valLoopback4",
+	} {
+		visitor := new(search.VisitorFirst[string, string])
+		require.NoError(t, router.Search(visitor, host, "/"), host)
+
+		if assert.NotNil(t, visitor.Result, host) {
+			assert.Equal(t, expected, visitor.Result.Value, host)
+		}
+	}
+
+	for _, host := range []string{"a..example.com", "*.example.com", ":x.example.com"} {
+		visitor := new(search.VisitorFirst[string, string])
+		assert.ErrorIs(t, router.Search(visitor, host, "/"), component.ErrInvalidHost, host)
+	}
+
+	assert.ErrorIs(t, router.Add("bad_label-.com", "/", "valBad"), component.ErrInvalidHost)
+}
@@ -1,6 +1,6 @@
 module github.com/oligarch316/go-urlrouter
 
-go 1.18
+go 1.22
 
 require github.com/stretchr/testify v1.7.0
 
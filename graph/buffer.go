@@ -0,0 +1,17 @@
+package graph
+
+// SearchBuffer provides reusable storage for allocation free searches.
+//
+// Every result visited during a buffered search is the same Result, overwritten
+// in place. Results (including Parameters, ParameterNames, ParameterValues and
+// Tail) remain valid only until the next result is visited or the buffer is
+// reused.
+type SearchBuffer[V any, P comparable] struct {
+	Count           int
+	ParameterValues []string
+	Result          SearchResult[V, P]
+}
+
+type BufferedTree[V any, P comparable] interface {
+	SearchBuffered(*SearchBuffer[V, P], Searcher[V, P], ...string)
+}
@@ -0,0 +1,15 @@
+package graph
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrSearchBudget = errors.New("search budget exceeded")
+
+// ContextTree searches subject to cancellation, and optionally a bound on the
+// number of steps taken before failing with ErrSearchBudget.
+type ContextTree[V any, P comparable] interface {
+	SearchContext(context.Context, Searcher[V, P], ...string) error
+	SearchBudget(ctx context.Context, steps int, searcher Searcher[V, P], query ...string) error
+}
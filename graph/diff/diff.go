@@ -0,0 +1,188 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+)
+
+const formatNone = "<none>"
+
+// Route is a terminal of a compared tree. Pattern is empty unless set by a
+// caller able to format Path, as with component.Diff.
+type Route[V any] struct {
+	Path    []graph.Key
+	Pattern string
+	Value   V
+}
+
+func (r Route[_]) String() string {
+	if r.Pattern == "" {
+		return graph.FormatPath(r.Value, r.Path...)
+	}
+	return graph.FormatQuery(r.Pattern, graph.FormatValue(r.Value))
+}
+
+type Change[V any] struct{ Old, New Route[V] }
+
+type Resolution[V any, P comparable] struct {
+	Query    string
+	Old, New *graph.SearchResult[V, P]
+}
+
+func formatResult[V any, P comparable](result *graph.SearchResult[V, P]) string {
+	if result == nil {
+		return formatNone
+	}
+	return graph.FormatValue(result.Value)
+}
+
+type Report[V any, P comparable] struct {
+	Added       []Route[V]
+	Removed     []Route[V]
+	Changed     []Change[V]
+	Resolutions []Resolution[V, P]
+}
+
+func (r Report[_, _]) Empty() bool {
+	return len(r.Added)+len(r.Removed)+len(r.Changed)+len(r.Resolutions) == 0
+}
+
+func (r Report[_, _]) String() string {
+	var lines []string
+
+	for _, route := range r.Added {
+		lines = append(lines, "+ "+route.String())
+	}
+
+	for _, route := range r.Removed {
+		lines = append(lines, "- "+route.String())
+	}
+
+	for _, change := range r.Changed {
+		lines = append(lines, fmt.Sprintf("~ %s => %s", change.Old, change.New))
+	}
+
+	for _, res := range r.Resolutions {
+		lines = append(lines, fmt.Sprintf("! %s: %s => %s", res.Query, formatResult(res.Old), formatResult(res.New)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Routes are identified by shape, meaning a renamed parameter is reported as a
+// change rather than a removal and addition
+func shape(path []graph.Key) string {
+	strs := make([]string, len(path))
+	for i, key := range path {
+		switch key.(type) {
+		case graph.KeyConstant, graph.KeyWildcard, nil:
+			strs[i] = graph.FormatKey(key)
+		default:
+			strs[i] = "param"
+		}
+	}
+	return graph.FormatQuery(strs...)
+}
+
+type routeEntry[V any] struct {
+	id    string
+	route Route[V]
+}
+
+func routes[V any, P comparable](view *priority.ViewNode[V, P]) ([]routeEntry[V], map[string]Route[V]) {
+	var (
+		terminals = view.Terminals()
+		list      = make([]routeEntry[V], len(terminals))
+		index     = make(map[string]Route[V], len(terminals))
+	)
+
+	// NOTE: Values coexisting at one terminal are matched by registration order
+	occurrences := make(map[string]int)
+
+	for i, terminal := range terminals {
+		list[i] = routeEntry[V]{
+			id:    routeID(terminal.Path, occurrences),
+			route: Route[V]{Path: terminal.Path, Value: terminal.Value},
+		}
+		index[list[i].id] = list[i].route
+	}
+
+	return list, index
+}
+
+func routeID(path []graph.Key, occurrences map[string]int) string {
+	id := shape(path)
+	n := occurrences[id]
+	occurrences[id]++
+
+	if n == 0 {
+		return id
+	}
+	return fmt.Sprintf("%s#%d", id, n)
+}
+
+type Differ[V any, P comparable] struct{ Equal func(a, b V) bool }
+
+func (d Differ[V, P]) equal(a, b V) bool {
+	if d.Equal == nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return d.Equal(a, b)
+}
+
+func (d Differ[V, P]) Views(before, after *priority.ViewNode[V, P]) Report[V, P] {
+	var (
+		res                     = Report[V, P]{}
+		beforeList, beforeIndex = routes(before)
+		afterList, afterIndex   = routes(after)
+	)
+
+	for _, entry := range beforeList {
+		if _, ok := afterIndex[entry.id]; !ok {
+			res.Removed = append(res.Removed, entry.route)
+		}
+	}
+
+	for _, entry := range afterList {
+		beforeRoute, ok := beforeIndex[entry.id]
+
+		switch {
+		case !ok:
+			res.Added = append(res.Added, entry.route)
+		case !reflect.DeepEqual(beforeRoute.Path, entry.route.Path) || !d.equal(beforeRoute.Value, entry.route.Value):
+			res.Changed = append(res.Changed, Change[V]{Old: beforeRoute, New: entry.route})
+		}
+	}
+
+	return res
+}
+
+func (d Differ[V, P]) Resolve(report *Report[V, P], query string, before, after *graph.SearchResult[V, P]) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before != nil && after != nil && d.equal(before.Value, after.Value):
+		return
+	}
+
+	report.Resolutions = append(report.Resolutions, Resolution[V, P]{Query: query, Old: before, New: after})
+}
+
+func (d Differ[V, P]) Diff(before, after priority.Tree[V, P], queries ...[]string) Report[V, P] {
+	res := d.Views(before.View(), after.View())
+
+	for _, query := range queries {
+		d.Resolve(&res, graph.FormatQuery(query...), search.First[V, P](before, query...), search.First[V, P](after, query...))
+	}
+
+	return res
+}
+
+func Diff[V any, P comparable](before, after priority.Tree[V, P], queries ...[]string) Report[V, P] {
+	return Differ[V, P]{}.Diff(before, after, queries...)
+}
@@ -0,0 +1,102 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/diff"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphDiff(t *testing.T) {
+	var (
+		a      = graph.KeyConstant("a")
+		b      = graph.KeyConstant("b")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		wild   = graph.KeyWildcard{}
+
+		before, after priority.Tree[string, string]
+	)
+
+	require.NoError(t, before.Add("valA", a))
+	require.NoError(t, before.Add("valParam", param1))
+	require.NoError(t, before.Add("valParamB", param1, b))
+	require.NoError(t, before.Add("valWild", wild))
+
+	require.NoError(t, after.Add("valA", a))
+	require.NoError(t, after.Add("valParam", param2))
+	require.NoError(t, after.Add("valParamBChanged", param1, b))
+	require.NoError(t, after.Add("valAWild", a, wild))
+
+	report := diff.Diff(before, after,
+		[]string{"a"},
+		[]string{"x"},
+		[]string{"x", "b"},
+		[]string{"a", "x"},
+		[]string{"x", "y"},
+	)
+
+	assert.Equal(t, []diff.Route[string]{
+		{Path: []graph.Key{a, wild}, Value: "valAWild"},
+	}, report.Added)
+
+	assert.Equal(t, []diff.Route[string]{
+		{Path: []graph.Key{wild}, Value: "valWild"},
+	}, report.Removed)
+
+	assert.Equal(t, []diff.Change[string]{
+		{
+			Old: diff.Route[string]{Path: []graph.Key{param1}, Value: "valParam"},
+			New: diff.Route[string]{Path: []graph.Key{param2}, Value: "valParam"},
+		},
+		{
+			Old: diff.Route[string]{Path: []graph.Key{param1, b}, Value: "valParamB"},
+			New: diff.Route[string]{Path: []graph.Key{param1, b}, Value: "valParamBChanged"},
+		},
+	}, report.Changed)
+
+	var resolved []string
+	for _, res := range report.Resolutions {
+		resolved = append(resolved, res.Query)
+	}
+
+	assert.Equal(t, []string{"x→b", "a→x", "x→y"}, resolved)
+	assert.False(t, report.Empty())
+}
+
+func TestGraphDiffEmpty(t *testing.T) {
+	var before, after priority.Tree[string, string]
+
+	require.NoError(t, before.Add("val", graph.KeyConstant("a")))
+	require.NoError(t, after.Add("val", graph.KeyConstant("a")))
+
+	report := diff.Diff(before, after, []string{"a"}, []string{"b"})
+	assert.True(t, report.Empty(), report.String())
+}
+
+func TestGraphDiffCoexist(t *testing.T) {
+	var (
+		a       = graph.KeyConstant("a")
+		coexist = func(existing, value string) bool { return true }
+
+		before = priority.Tree[string, string]{Coexist: coexist}
+		after  = priority.Tree[string, string]{Coexist: coexist}
+	)
+
+	require.NoError(t, before.Add("valFirst", a))
+
+	require.NoError(t, after.Add("valFirst", a))
+	require.NoError(t, after.Add("valSecond", a))
+
+	report := diff.Diff(before, after)
+
+	assert.Equal(t, []diff.Route[string]{
+		{Path: []graph.Key{a}, Value: "valSecond"},
+	}, report.Added)
+
+	assert.Empty(t, report.Removed)
+	assert.Empty(t, report.Changed)
+}
@@ -0,0 +1,70 @@
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+)
+
+const (
+	attrsNode     = `shape=circle, label=""`
+	attrsRoot     = `shape=doublecircle, label="root"`
+	attrsTerminal = `shape=box`
+)
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+type encoder[V any, P comparable] struct {
+	w     *bufio.Writer
+	count int
+}
+
+func (e *encoder[V, P]) id() string {
+	res := fmt.Sprintf("n%d", e.count)
+	e.count++
+	return res
+}
+
+func (e *encoder[V, P]) node(id string, node *priority.ViewNode[V, P]) {
+	for _, edge := range node.Edges {
+		childID := e.id()
+
+		switch {
+		case edge.Node != nil:
+			fmt.Fprintf(e.w, "\t%s [%s];\n", childID, attrsNode)
+		case len(edge.Terminals) > 0:
+			fmt.Fprintf(e.w, "\t%s [%s, label=%s];\n", childID, attrsTerminal, quote(edge.FormatValues()))
+		}
+
+		fmt.Fprintf(e.w, "\t%s -> %s [label=%s];\n", id, childID, quote(edge.String()))
+
+		if edge.Node != nil {
+			e.node(childID, edge.Node)
+		}
+	}
+}
+
+func Encode[V any, P comparable](w io.Writer, tree priority.Tree[V, P]) error {
+	enc := &encoder[V, P]{w: bufio.NewWriter(w)}
+	rootID := enc.id()
+
+	fmt.Fprintln(enc.w, "digraph tree {")
+	fmt.Fprintf(enc.w, "\t%s [%s];\n", rootID, attrsRoot)
+	enc.node(rootID, tree.View())
+	fmt.Fprintln(enc.w, "}")
+
+	return enc.w.Flush()
+}
+
+func Format[V any, P comparable](tree priority.Tree[V, P]) string {
+	var sb strings.Builder
+	Encode(&sb, tree)
+	return sb.String()
+}
@@ -0,0 +1,48 @@
+package dot_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/dot"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphDotFormat(t *testing.T) {
+	var (
+		a      = graph.KeyConstant("a")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		wild   = graph.KeyWildcard{}
+
+		tree priority.Tree[string, string]
+	)
+
+	require.NoError(t, tree.Add("valRoot"))
+	require.NoError(t, tree.Add("valA", a))
+	require.NoError(t, tree.Add("valParam1", param1, a))
+	require.NoError(t, tree.Add("valParam2", param2, wild))
+
+	expected := `digraph tree {
+	n0 [shape=doublecircle, label="root"];
+	n1 [shape=box, label="value(valRoot)"];
+	n0 -> n1 [label="value"];
+	n2 [shape=circle, label=""];
+	n0 -> n2 [label="const(a)"];
+	n3 [shape=box, label="value(valA)"];
+	n2 -> n3 [label="value"];
+	n4 [shape=circle, label=""];
+	n0 -> n4 [label="param(param1 | param2)"];
+	n5 [shape=circle, label=""];
+	n4 -> n5 [label="const(a)"];
+	n6 [shape=box, label="value(valParam1)"];
+	n5 -> n6 [label="value"];
+	n7 [shape=box, label="value(valParam2)"];
+	n4 -> n7 [label="wild"];
+}
+`
+
+	assert.Equal(t, expected, dot.Format(tree))
+}
@@ -1,16 +1,45 @@
 package graph
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrInternal = errors.New("internal")
-	ErrNilKey   = errors.New("nil key")
+	ErrInternal   = errors.New("internal")
+	ErrInvalidKey = errors.New("invalid key")
+	ErrNilKey     = errors.New("nil key")
 )
 
 type DuplicateValueError[V any] struct{ ExistingValue V }
 
-func (dve DuplicateValueError[_]) Error() string { return "dupliate value" }
+func (dve DuplicateValueError[_]) Error() string { return "duplicate value" }
 
 type InvalidContinuationError struct{ Continuation []Key }
 
 func (ice InvalidContinuationError) Error() string { return "invalid continuation" }
+
+// BatchAddError is the error returned by the Add call at Index, counting from
+// zero, within a batch.
+type BatchAddError struct {
+	Index int
+	Err   error
+}
+
+func (bae BatchAddError) Error() string { return fmt.Sprintf("add %d: %s", bae.Index, bae.Err) }
+func (bae BatchAddError) Unwrap() error { return bae.Err }
+
+type BatchError struct{ Errors []error }
+
+func (be BatchError) Error() string {
+	switch len(be.Errors) {
+	case 0:
+		return "batch failed"
+	case 1:
+		return fmt.Sprintf("batch failed: %s", be.Errors[0])
+	}
+
+	return fmt.Sprintf("batch failed: %s (and %d more)", be.Errors[0], len(be.Errors)-1)
+}
+
+func (be BatchError) Unwrap() []error { return be.Errors }
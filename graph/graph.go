@@ -1,18 +1,75 @@
 package graph
 
-type SearchResult[V any] struct {
-	Parameters map[string]string
+import "context"
+
+type SearchResult[V any, P comparable] struct {
+	Parameters map[P]string
 	Tail       []string
 	Value      V
+
+	// Rank is the order in which this result was visited during its search
+	Rank int
+
+	// Path is the key path the matched value was added with. It is shared with
+	// the tree and must not be modified
+	Path []Key
+
+	// Parameter names and values in pattern order, including duplicate names
+	// which Parameters collapses into a single entry. ParameterNames is shared
+	// with the tree and must not be modified
+	ParameterNames  []P
+	ParameterValues []string
 }
 
-type Searcher[V any] interface {
-	VisitSearch(result *SearchResult[V]) (done bool)
+func (sr SearchResult[_, _]) Specificity() Specificity {
+	res := Specificity{Tail: len(sr.Tail)}
+
+	for _, key := range sr.Path {
+		switch key.(type) {
+		case KeyConstant:
+			res.Constants++
+		case KeyWildcard:
+			res.Wildcard = true
+		default:
+			res.Parameters++
+		}
+	}
+
+	return res
 }
 
-type SearcherFunc[V any] func(result *SearchResult[V]) (done bool)
+func (sr SearchResult[_, _]) NumParams() int { return len(sr.ParameterValues) }
 
-func (sf SearcherFunc[V]) VisitSearch(result *SearchResult[V]) bool { return sf(result) }
+func (sr SearchResult[_, _]) Param(i int) string {
+	if i < 0 || i >= len(sr.ParameterValues) {
+		return ""
+	}
+	return sr.ParameterValues[i]
+}
+
+func (sr SearchResult[_, P]) ParamName(i int) P {
+	if i < 0 || i >= len(sr.ParameterNames) {
+		var zero P
+		return zero
+	}
+	return sr.ParameterNames[i]
+}
+
+type Searcher[V any, P comparable] interface {
+	VisitSearch(result *SearchResult[V, P]) (done bool)
+}
+
+type SearcherFunc[V any, P comparable] func(result *SearchResult[V, P]) (done bool)
+
+func (sf SearcherFunc[V, P]) VisitSearch(result *SearchResult[V, P]) bool { return sf(result) }
+
+// Guard decides whether a matched value applies to the search in progress.
+// Searches without a context of their own are guarded by context.Background().
+type Guard[V any, P comparable] func(ctx context.Context, result *SearchResult[V, P]) bool
+
+type GuardedAdder[V any, P comparable] interface {
+	AddGuarded(V, Guard[V, P], ...Key) error
+}
 
 type Walker[V any] interface {
 	VisitWalk(value V) (done bool)
@@ -22,8 +79,16 @@ type WalkerFunc[V any] func(value V) (done bool)
 
 func (wf WalkerFunc[V]) VisitWalk(value V) bool { return wf(value) }
 
-type Tree[V any] interface {
+type Adder[V any] interface {
+	Add(V, ...Key) error
+}
+
+type Batcher[V any] interface {
+	Batch(func(Adder[V]) error) error
+}
+
+type Tree[V any, P comparable] interface {
 	Add(V, ...Key) error
-	Search(Searcher[V], ...string)
+	Search(Searcher[V, P], ...string)
 	Walk(Walker[V])
 }
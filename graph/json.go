@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	jsonKindConstant  = "constant"
+	jsonKindParameter = "parameter"
+	jsonKindWildcard  = "wildcard"
+)
+
+var ErrInvalidJSONKey = errors.New("invalid json key")
+
+type jsonKey struct {
+	Kind string          `json:"kind"`
+	Name json.RawMessage `json:"name,omitempty"`
+}
+
+func marshalKeyJSON(kind string, name interface{}) ([]byte, error) {
+	data, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonKey{Kind: kind, Name: data})
+}
+
+func (kc KeyConstant) MarshalJSON() ([]byte, error) {
+	return marshalKeyJSON(jsonKindConstant, string(kc))
+}
+
+func (kp KeyParameter[_]) MarshalJSON() ([]byte, error) {
+	return marshalKeyJSON(jsonKindParameter, kp.Name)
+}
+
+func (KeyWildcard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonKey{Kind: jsonKindWildcard})
+}
+
+func unmarshalKeyJSON(data []byte, expected string) (json.RawMessage, error) {
+	var raw jsonKey
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	if raw.Kind != expected {
+		return nil, fmt.Errorf("%w: expected %s, got '%s'", ErrInvalidJSONKey, expected, raw.Kind)
+	}
+
+	switch {
+	case expected == jsonKindWildcard && raw.Name != nil:
+		return nil, fmt.Errorf("%w: unexpected %s name", ErrInvalidJSONKey, raw.Kind)
+	case expected != jsonKindWildcard && raw.Name == nil:
+		return nil, fmt.Errorf("%w: missing %s name", ErrInvalidJSONKey, raw.Kind)
+	}
+
+	return raw.Name, nil
+}
+
+func (kc *KeyConstant) UnmarshalJSON(data []byte) error {
+	name, err := unmarshalKeyJSON(data, jsonKindConstant)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(name, (*string)(kc))
+}
+
+func (kp *KeyParameter[_]) UnmarshalJSON(data []byte) error {
+	name, err := unmarshalKeyJSON(data, jsonKindParameter)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(name, &kp.Name)
+}
+
+func (kw *KeyWildcard) UnmarshalJSON(data []byte) error {
+	_, err := unmarshalKeyJSON(data, jsonKindWildcard)
+	return err
+}
+
+func UnmarshalKeyJSON[P comparable](data []byte) (Key, error) {
+	var raw jsonKey
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var (
+		key Key
+		err error
+	)
+
+	switch raw.Kind {
+	case jsonKindConstant:
+		var kc KeyConstant
+		err = kc.UnmarshalJSON(data)
+		key = kc
+	case jsonKindParameter:
+		var kp KeyParameter[P]
+		err = kp.UnmarshalJSON(data)
+		key = kp
+	case jsonKindWildcard:
+		var kw KeyWildcard
+		err = kw.UnmarshalJSON(data)
+		key = kw
+	default:
+		err = fmt.Errorf("%w: unknown kind '%s'", ErrInvalidJSONKey, raw.Kind)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// KeyPath is a []Key that can be unmarshaled from JSON, with parameter names of type P.
+type KeyPath[P comparable] []Key
+
+func (kp KeyPath[_]) MarshalJSON() ([]byte, error) {
+	for _, key := range kp {
+		if key == nil {
+			return nil, ErrNilKey
+		}
+	}
+
+	return json.Marshal([]Key(kp))
+}
+
+func (kp *KeyPath[P]) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	res := make(KeyPath[P], len(raws))
+	for i, raw := range raws {
+		key, err := UnmarshalKeyJSON[P](raw)
+		if err != nil {
+			return err
+		}
+
+		res[i] = key
+	}
+
+	*kp = res
+	return nil
+}
+
+type ValueCodec[V any] interface {
+	EncodeValue(V) ([]byte, error)
+	DecodeValue([]byte) (V, error)
+}
+
+type JSONValueCodec[V any] struct{}
+
+func (JSONValueCodec[V]) EncodeValue(value V) ([]byte, error) { return json.Marshal(value) }
+
+func (JSONValueCodec[V]) DecodeValue(data []byte) (V, error) {
+	var res V
+	err := json.Unmarshal(data, &res)
+	return res, err
+}
@@ -0,0 +1,48 @@
+package graph_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphKeyPathJSON(t *testing.T) {
+	var (
+		path = graph.KeyPath[string]{
+			graph.KeyConstant("a"),
+			graph.KeyParameter[string]{Name: "param"},
+			graph.KeyWildcard{},
+		}
+
+		expected = `[{"kind":"constant","name":"a"},{"kind":"parameter","name":"param"},{"kind":"wildcard"}]`
+	)
+
+	data, err := json.Marshal(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(data))
+
+	var actual graph.KeyPath[string]
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, path, actual)
+}
+
+func TestGraphKeyJSONError(t *testing.T) {
+	inputs := []string{
+		`{"kind":"constant"}`,
+		`{"kind":"parameter"}`,
+		`{"kind":"wildcard","name":"any"}`,
+		`{"kind":"unknown","name":"any"}`,
+	}
+
+	for _, input := range inputs {
+		_, err := graph.UnmarshalKeyJSON[string]([]byte(input))
+		assert.ErrorIs(t, err, graph.ErrInvalidJSONKey, input)
+	}
+
+	var constant graph.KeyConstant
+	err := json.Unmarshal([]byte(`{"kind":"parameter","name":"param"}`), &constant)
+	assert.ErrorIs(t, err, graph.ErrInvalidJSONKey)
+}
@@ -8,15 +8,15 @@ type Key interface {
 }
 
 type (
-	KeyConstant  string
-	KeyParameter string
-	KeyWildcard  struct{}
+	KeyConstant                string
+	KeyParameter[P comparable] struct{ Name P }
+	KeyWildcard                struct{}
 )
 
-func (KeyConstant) sealedKey()  {}
-func (KeyParameter) sealedKey() {}
-func (KeyWildcard) sealedKey()  {}
+func (KeyConstant) sealedKey()     {}
+func (KeyParameter[_]) sealedKey() {}
+func (KeyWildcard) sealedKey()     {}
 
-func (KeyWildcard) String() string     { return "wild" }
-func (kc KeyConstant) String() string  { return fmt.Sprintf("const(%s)", string(kc)) }
-func (kp KeyParameter) String() string { return fmt.Sprintf("param(%s)", string(kp)) }
+func (KeyWildcard) String() string        { return "wild" }
+func (kc KeyConstant) String() string     { return fmt.Sprintf("const(%s)", string(kc)) }
+func (kp KeyParameter[_]) String() string { return fmt.Sprintf("param(%v)", kp.Name) }
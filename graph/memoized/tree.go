@@ -1,6 +1,8 @@
 package memoized
 
 import (
+	"context"
+
 	"github.com/oligarch316/go-urlrouter/graph"
 	"github.com/oligarch316/go-urlrouter/graph/priority"
 )
@@ -12,16 +14,34 @@ type Memo[V any] struct {
 
 func (m Memo[_]) String() string { return graph.FormatPath(m.Value, m.Path...) }
 
-func wrapSearcher[V any](searcher graph.Searcher[V]) graph.Searcher[Memo[V]] {
-	wrapped := func(memoResult *graph.SearchResult[Memo[V]]) bool {
-		return searcher.VisitSearch(&graph.SearchResult[V]{
-			Parameters: memoResult.Parameters,
-			Tail:       memoResult.Tail,
-			Value:      memoResult.Value.Value,
-		})
+func unwrapResult[V any, P comparable](memoResult *graph.SearchResult[Memo[V], P]) *graph.SearchResult[V, P] {
+	return &graph.SearchResult[V, P]{
+		Parameters:      memoResult.Parameters,
+		Path:            memoResult.Path,
+		Rank:            memoResult.Rank,
+		Tail:            memoResult.Tail,
+		Value:           memoResult.Value.Value,
+		ParameterNames:  memoResult.ParameterNames,
+		ParameterValues: memoResult.ParameterValues,
+	}
+}
+
+func wrapSearcher[V any, P comparable](searcher graph.Searcher[V, P]) graph.Searcher[Memo[V], P] {
+	wrapped := func(memoResult *graph.SearchResult[Memo[V], P]) bool {
+		return searcher.VisitSearch(unwrapResult(memoResult))
+	}
+
+	return graph.SearcherFunc[Memo[V], P](wrapped)
+}
+
+func wrapGuard[V any, P comparable](guard graph.Guard[V, P]) graph.Guard[Memo[V], P] {
+	if guard == nil {
+		return nil
 	}
 
-	return graph.SearcherFunc[Memo[V]](wrapped)
+	return func(ctx context.Context, memoResult *graph.SearchResult[Memo[V], P]) bool {
+		return guard(ctx, unwrapResult(memoResult))
+	}
 }
 
 func wrapWalker[V any](walker graph.Walker[V]) graph.Walker[Memo[V]] {
@@ -32,33 +52,107 @@ func wrapWalker[V any](walker graph.Walker[V]) graph.Walker[Memo[V]] {
 	return graph.WalkerFunc[Memo[V]](wrapped)
 }
 
-type Tree[V any] struct{ Memoized priority.Tree[Memo[V]] }
-
-func (t *Tree[V]) Add(value V, path ...graph.Key) error {
-	var (
-		memo = Memo[V]{Path: path, Value: value}
-		err  = t.Memoized.Add(memo, path...)
-	)
+func unwrapError[V any](err error) error {
+	if addErr, ok := err.(graph.BatchAddError); ok {
+		addErr.Err = unwrapError[V](addErr.Err)
+		return addErr
+	}
 
 	if dupErr, ok := err.(graph.DuplicateValueError[Memo[V]]); ok {
-		err = graph.DuplicateValueError[V]{ExistingValue: dupErr.ExistingValue.Value}
+		return graph.DuplicateValueError[V]{ExistingValue: dupErr.ExistingValue.Value}
 	}
 
 	return err
 }
 
-func (t Tree[V]) Search(searcher graph.Searcher[V], query ...string) {
+type memoAdder[V any] struct{ adder graph.Adder[Memo[V]] }
+
+func (ma memoAdder[V]) Add(value V, path ...graph.Key) error {
+	memo := Memo[V]{Path: path, Value: value}
+	return unwrapError[V](ma.adder.Add(memo, path...))
+}
+
+type Tree[V any, P comparable] struct{ Memoized priority.Tree[Memo[V], P] }
+
+func (t *Tree[V, P]) Add(value V, path ...graph.Key) error {
+	return memoAdder[V]{adder: &t.Memoized}.Add(value, path...)
+}
+
+func (t *Tree[V, P]) AddGuarded(value V, guard graph.Guard[V, P], path ...graph.Key) error {
+	memo := Memo[V]{Path: path, Value: value}
+	return unwrapError[V](t.Memoized.AddGuarded(memo, wrapGuard(guard), path...))
+}
+
+// NOTE: Add errors keep the batch index they were returned with, so the
+// underlying batch recognizes them when returned by fn.
+func (t *Tree[V, P]) Batch(fn func(tx graph.Adder[V]) error) error {
+	err := t.Memoized.Batch(func(tx graph.Adder[Memo[V]]) error {
+		return fn(memoAdder[V]{adder: tx})
+	})
+
+	if batchErr, ok := err.(graph.BatchError); ok {
+		for i, item := range batchErr.Errors {
+			batchErr.Errors[i] = unwrapError[V](item)
+		}
+	}
+
+	return err
+}
+
+func (t Tree[V, P]) Search(searcher graph.Searcher[V, P], query ...string) {
 	t.Memoized.Search(wrapSearcher(searcher), query...)
 }
 
-func (t Tree[V]) SearchFunc(searcher func(result *graph.SearchResult[V]) (done bool), query ...string) {
-	t.SearchFunc(graph.SearcherFunc[V](searcher), query...)
+func (t Tree[V, P]) SearchContext(ctx context.Context, searcher graph.Searcher[V, P], query ...string) error {
+	return t.Memoized.SearchContext(ctx, wrapSearcher(searcher), query...)
 }
 
-func (t Tree[V]) Walk(walker graph.Walker[V]) {
+func (t Tree[V, P]) SearchBudget(ctx context.Context, steps int, searcher graph.Searcher[V, P], query ...string) error {
+	return t.Memoized.SearchBudget(ctx, steps, wrapSearcher(searcher), query...)
+}
+
+func (t Tree[V, P]) SearchFunc(searcher func(result *graph.SearchResult[V, P]) (done bool), query ...string) {
+	t.Search(graph.SearcherFunc[V, P](searcher), query...)
+}
+
+func (t Tree[V, P]) Walk(walker graph.Walker[V]) {
 	t.Memoized.Walk(wrapWalker(walker))
 }
 
-func (t Tree[V]) WalkFunc(walker func(value V) (done bool)) {
-	t.WalkFunc(graph.WalkerFunc[V](walker))
+func (t Tree[V, P]) WalkFunc(walker func(value V) (done bool)) {
+	t.Walk(graph.WalkerFunc[V](walker))
+}
+
+func unwrapView[V any, P comparable](memoNode *priority.ViewNode[Memo[V], P]) *priority.ViewNode[V, P] {
+	res := &priority.ViewNode[V, P]{Edges: make([]priority.ViewEdge[V, P], len(memoNode.Edges))}
+
+	for i, memoEdge := range memoNode.Edges {
+		edge := priority.ViewEdge[V, P]{
+			Kind:     memoEdge.Kind,
+			Constant: memoEdge.Constant,
+			Arity:    memoEdge.Arity,
+			Names:    memoEdge.Names,
+		}
+
+		if memoEdge.Node != nil {
+			edge.Node = unwrapView(memoEdge.Node)
+		}
+
+		for _, memoTerminal := range memoEdge.Terminals {
+			edge.Terminals = append(edge.Terminals, &priority.ViewTerminal[V, P]{
+				Guarded:  memoTerminal.Guarded,
+				Path:     memoTerminal.Path,
+				Sequence: memoTerminal.Sequence,
+				Value:    memoTerminal.Value.Value,
+			})
+		}
+
+		res.Edges[i] = edge
+	}
+
+	return res
+}
+
+func (t Tree[V, P]) View() *priority.ViewNode[V, P] {
+	return unwrapView(t.Memoized.View())
 }
@@ -0,0 +1,56 @@
+package pretty
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+)
+
+const (
+	prefixBranch = "├── "
+	prefixLast   = "└── "
+	indentBranch = "│   "
+	indentLast   = "    "
+
+	labelRoot = "root"
+)
+
+type printer[V any, P comparable] struct{ w *bufio.Writer }
+
+func (p printer[V, P]) node(indent string, node *priority.ViewNode[V, P]) {
+	for i, edge := range node.Edges {
+		prefix, childIndent := prefixBranch, indentBranch
+		if i == len(node.Edges)-1 {
+			prefix, childIndent = prefixLast, indentLast
+		}
+
+		label := edge.String()
+		if len(edge.Terminals) > 0 {
+			label = fmt.Sprintf("%s → %s", label, edge.FormatValues())
+		}
+
+		fmt.Fprintf(p.w, "%s%s%s\n", indent, prefix, label)
+
+		if edge.Node != nil {
+			p.node(indent+childIndent, edge.Node)
+		}
+	}
+}
+
+func Encode[V any, P comparable](w io.Writer, tree priority.Tree[V, P]) error {
+	p := printer[V, P]{w: bufio.NewWriter(w)}
+
+	fmt.Fprintln(p.w, labelRoot)
+	p.node("", tree.View())
+
+	return p.w.Flush()
+}
+
+func Format[V any, P comparable](tree priority.Tree[V, P]) string {
+	var sb strings.Builder
+	Encode(&sb, tree)
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package pretty_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/pretty"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphPrettyFormat(t *testing.T) {
+	var (
+		a      = graph.KeyConstant("a")
+		b      = graph.KeyConstant("b")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		wild   = graph.KeyWildcard{}
+
+		tree priority.Tree[string, string]
+	)
+
+	require.NoError(t, tree.Add("valRoot"))
+	require.NoError(t, tree.Add("valA", a))
+	require.NoError(t, tree.Add("valAWild", a, wild))
+	require.NoError(t, tree.Add("valParamB", param1, b))
+	require.NoError(t, tree.Add("valParamParam", param1, param2))
+	require.NoError(t, tree.Add("valWild", wild))
+
+	expected := `root
+├── value → value(valRoot)
+├── const(a)
+│   ├── value → value(valA)
+│   └── wild → value(valAWild)
+├── param(param1)
+│   └── const(b)
+│       └── value → value(valParamB)
+├── param(param1,param2)
+│   └── value → value(valParamParam)
+└── wild → value(valWild)
+`
+
+	assert.Equal(t, expected, pretty.Format(tree))
+}
+
+func TestGraphPrettyFormatEmpty(t *testing.T) {
+	assert.Equal(t, "root\n", pretty.Format(priority.Tree[string, string]{}))
+}
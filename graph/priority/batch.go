@@ -0,0 +1,121 @@
+package priority
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+func (esc edgeSetConstant[V, P]) clone() edgeSetConstant[V, P] {
+	if esc == nil {
+		return nil
+	}
+
+	res := make(edgeSetConstant[V, P], len(esc))
+	for e, node := range esc {
+		res[e] = node.clone()
+	}
+
+	return res
+}
+
+func (esp edgeSetParameter[V, P]) clone() edgeSetParameter[V, P] {
+	if esp.nMap == nil {
+		return edgeSetParameter[V, P]{}
+	}
+
+	res := edgeSetParameter[V, P]{
+		nList: append(sort.IntSlice(nil), esp.nList...),
+		nMap:  make(map[int]*nodeParameter[V, P], len(esp.nMap)),
+	}
+
+	for n, node := range esp.nMap {
+		res.nMap[n] = node.clone()
+	}
+
+	return res
+}
+
+func (nc *nodeConstant[V, P]) clone() *nodeConstant[V, P] {
+	return &nodeConstant[V, P]{
+		constantEdges:  nc.constantEdges.clone(),
+		parameterEdges: nc.parameterEdges.clone(),
+		valueEdges:     nc.valueEdges,
+		wildcardEdges:  nc.wildcardEdges,
+	}
+}
+
+func (np *nodeParameter[V, P]) clone() *nodeParameter[V, P] {
+	return &nodeParameter[V, P]{
+		constantEdges: np.constantEdges.clone(),
+		valueEdges:    np.valueEdges,
+		wildcardEdges: np.wildcardEdges,
+	}
+}
+
+type batchTx[V any, P comparable] struct {
+	tree   *Tree[V, P]
+	adds   int
+	failed []graph.BatchAddError
+}
+
+func (bt *batchTx[V, P]) Add(value V, path ...graph.Key) error {
+	index := bt.adds
+	bt.adds++
+
+	if err := bt.tree.Add(value, path...); err != nil {
+		addErr := graph.BatchAddError{Index: index, Err: err}
+		bt.failed = append(bt.failed, addErr)
+		return addErr
+	}
+
+	return nil
+}
+
+// NOTE: Errors are told apart by the index of their Add call rather than by
+// value, since distinct additions may well fail with equal errors.
+func (bt batchTx[V, P]) recorded(err error) bool {
+	var addErr graph.BatchAddError
+	if !errors.As(err, &addErr) {
+		return false
+	}
+
+	for _, item := range bt.failed {
+		if item.Index == addErr.Index {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NOTE: Batch operates on a copy of the tree and only swaps it in once every
+// addition has succeeded, so a failed batch leaves the tree untouched.
+func (t *Tree[V, P]) Batch(fn func(tx graph.Adder[V]) error) error {
+	clone := &Tree[V, P]{
+		Coexist:  t.Coexist,
+		Policy:   t.Policy,
+		root:     *t.root.clone(),
+		sequence: t.sequence,
+	}
+	tx := &batchTx[V, P]{tree: clone}
+
+	err := fn(tx)
+
+	errs := make([]error, 0, len(tx.failed)+1)
+	for _, addErr := range tx.failed {
+		errs = append(errs, addErr)
+	}
+
+	if err != nil && !tx.recorded(err) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return graph.BatchError{Errors: errs}
+	}
+
+	*t = *tx.tree
+	return nil
+}
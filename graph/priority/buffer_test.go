@@ -0,0 +1,131 @@
+package priority_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bufferTree(t testing.TB) *priority.Tree[string, string] {
+	return fixtureTree(t, new(priority.Tree[string, string]),
+		graphtest.Path("valA", keyA),
+		graphtest.Path("valParamB", keyParam1, keyB),
+		graphtest.Path("valParamParamB", keyParam1, keyParam2, keyB),
+		graphtest.Path("valParamWild", keyParam1, keyWild),
+		graphtest.Path("valWild", keyWild),
+	)
+}
+
+func TestGraphPrioritySearchBuffered(t *testing.T) {
+	var (
+		tree   = bufferTree(t)
+		buffer = new(graph.SearchBuffer[string, string])
+	)
+
+	queries := [][]string{
+		{"a"},
+		{"x", "b"},
+		{"x", "y", "b"},
+		{"x", "y", "z"},
+		{},
+	}
+
+	for _, query := range queries {
+		var (
+			expected = search.All[string, string](tree, query...)
+			actual   []graph.SearchResult[string, string]
+		)
+
+		tree.SearchBuffered(buffer, graph.SearcherFunc[string, string](func(result *graph.SearchResult[string, string]) bool {
+			res := *result
+			if len(res.Parameters) > 0 {
+				res.Parameters = make(map[string]string)
+				for key, val := range result.Parameters {
+					res.Parameters[key] = val
+				}
+			} else {
+				res.Parameters = nil
+			}
+
+			actual = append(actual, res)
+			return false
+		}), query...)
+
+		require.Len(t, actual, len(expected), graph.FormatQuery(query...))
+		assert.Equal(t, len(expected), buffer.Count, graph.FormatQuery(query...))
+
+		for i := range expected {
+			assert.Equal(t, *expected[i], actual[i], graph.FormatQuery(query...))
+		}
+	}
+}
+
+func TestGraphPrioritySearchBufferedAllocs(t *testing.T) {
+	var (
+		tree   = bufferTree(t)
+		pool   search.BufferPool[string, string]
+		buffer = pool.Get()
+		query  = []string{"x", "y", "b"}
+	)
+
+	defer pool.Put(buffer)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		search.FirstBuffered[string, string](tree, buffer, query...)
+	})
+
+	assert.Zero(t, allocs)
+
+	if actual := search.FirstBuffered[string, string](tree, buffer, query...); assert.NotNil(t, actual) {
+		assert.Equal(t, "valParamParamB", actual.Value)
+		assert.Equal(t, map[string]string{"param1": "x", "param2": "y"}, actual.Parameters)
+	}
+}
+
+func TestGraphPrioritySearchSharedNames(t *testing.T) {
+	var (
+		tree  = bufferTree(t)
+		query = []string{"x", "y", "b"}
+	)
+
+	first := search.First[string, string](tree, query...)
+	require.NotNil(t, first)
+
+	second := search.First[string, string](tree, query...)
+	require.NotNil(t, second)
+
+	assert.Same(t, &first.ParameterNames[0], &second.ParameterNames[0], "check shared")
+	assert.Equal(t, len(first.ParameterNames), cap(first.ParameterNames), "check capped")
+}
+
+func BenchmarkGraphPrioritySearchFirst(b *testing.B) {
+	var (
+		tree  = bufferTree(b)
+		query = []string{"x", "y", "b"}
+	)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		search.First[string, string](tree, query...)
+	}
+}
+
+func BenchmarkGraphPrioritySearchFirstBuffered(b *testing.B) {
+	var (
+		tree   = bufferTree(b)
+		buffer = new(graph.SearchBuffer[string, string])
+		query  = []string{"x", "y", "b"}
+	)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		search.FirstBuffered[string, string](tree, buffer, query...)
+	}
+}
@@ -0,0 +1,162 @@
+package priority_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Values are "<selector>:<name>", and may coexist when their selectors differ
+func coexistSelector(existing, value string) bool {
+	return strings.SplitN(existing, ":", 2)[0] != strings.SplitN(value, ":", 2)[0]
+}
+
+func coexistTree(t *testing.T) *priority.Tree[string, string] {
+	return fixtureTree(t, &priority.Tree[string, string]{Coexist: coexistSelector},
+		graphtest.Path("GET:valA", keyA),
+		graphtest.Path("POST:valA", keyA),
+		graphtest.Path("GET:valParam", keyParam1),
+		graphtest.Path("PUT:valParam", keyParam2),
+		graphtest.Path("GET:valWild", keyWild),
+	)
+}
+
+func TestGraphPriorityCoexist(t *testing.T) {
+	tree := coexistTree(t)
+
+	t.Run("search", func(t *testing.T) {
+		results := search.All[string, string](tree, "a")
+		assert.Equal(t, []string{"GET:valA", "POST:valA", "GET:valParam", "PUT:valParam", "GET:valWild"}, resultValues(results))
+
+		for i, result := range results {
+			assert.Equal(t, i, result.Rank, "check rank %d", i)
+		}
+
+		assert.Equal(t, map[string]string{"param1": "a"}, results[2].Parameters)
+		assert.Equal(t, map[string]string{"param2": "a"}, results[3].Parameters)
+	})
+
+	t.Run("first", func(t *testing.T) {
+		result := search.First[string, string](tree, "a")
+		if assert.NotNil(t, result) {
+			assert.Equal(t, "GET:valA", result.Value)
+		}
+	})
+
+	t.Run("walk", func(t *testing.T) {
+		var actual []string
+		tree.WalkFunc(func(value string) bool {
+			actual = append(actual, value)
+			return false
+		})
+
+		assert.ElementsMatch(t, []string{"GET:valA", "POST:valA", "GET:valParam", "PUT:valParam", "GET:valWild"}, actual)
+	})
+
+	t.Run("view", func(t *testing.T) {
+		var actual []string
+		for _, terminal := range tree.View().Terminals() {
+			actual = append(actual, terminal.Value)
+		}
+
+		assert.Equal(t, []string{"GET:valA", "POST:valA", "GET:valParam", "PUT:valParam", "GET:valWild"}, actual)
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		policyTree := *tree
+		policyTree.Policy = priority.PolicyLastMatch[string, string]{}
+
+		results := search.All[string, string](policyTree, "a")
+		assert.Equal(t, []string{"GET:valWild", "PUT:valParam", "GET:valParam", "POST:valA", "GET:valA"}, resultValues(results))
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		var (
+			codec = priority.SnapshotCodec[string, string]{}
+			buf   bytes.Buffer
+		)
+
+		require.NoError(t, priority.WriteSnapshot[string, string](&buf, *tree, codec))
+
+		loaded := &priority.Tree[string, string]{Coexist: tree.Coexist}
+		require.NoError(t, priority.ReadSnapshot(&buf, codec, loaded))
+
+		assert.Equal(t, tree.View(), loaded.View())
+		assert.Equal(t, resultValues(search.All[string, string](tree, "a")), resultValues(search.All[string, string](loaded, "a")))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(priority.JSONTree[string, string]{Tree: tree})
+		require.NoError(t, err)
+
+		loaded := priority.JSONTree[string, string]{Tree: &priority.Tree[string, string]{Coexist: tree.Coexist}}
+		require.NoError(t, json.Unmarshal(data, &loaded))
+
+		assert.Equal(t, tree.View(), loaded.Tree.View())
+		assert.Equal(t, resultValues(search.All[string, string](tree, "a")), resultValues(search.All[string, string](loaded.Tree, "a")))
+
+		err = priority.LoadJSON(data, graph.JSONValueCodec[string]{}, new(priority.Tree[string, string]))
+		assert.ErrorAs(t, err, new(graph.DuplicateValueError[string]), "check without coexist")
+	})
+}
+
+func TestGraphPriorityCoexistError(t *testing.T) {
+	param3 := graph.KeyParameter[string]{Name: "param3"}
+
+	t.Run("conflict", func(t *testing.T) {
+		var (
+			tree      = coexistTree(t)
+			targetErr graph.DuplicateValueError[string]
+		)
+
+		err := tree.Add("POST:valOther", keyA)
+		if assert.ErrorAs(t, err, &targetErr) {
+			assert.Equal(t, "POST:valA", targetErr.ExistingValue)
+		}
+
+		err = tree.Add("PUT:valOther", param3)
+		if assert.ErrorAs(t, err, &targetErr) {
+			assert.Equal(t, "PUT:valParam", targetErr.ExistingValue)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var (
+			tree      = fixtureTree(t, new(priority.Tree[string, string]), graphtest.Path("GET:valA", keyA))
+			targetErr graph.DuplicateValueError[string]
+		)
+
+		err := tree.Add("POST:valA", keyA)
+		if assert.ErrorAs(t, err, &targetErr) {
+			assert.Equal(t, "GET:valA", targetErr.ExistingValue)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		var (
+			tree     = coexistTree(t)
+			expected = tree.View()
+			errFail  = errors.New("fail")
+		)
+
+		err := tree.Batch(func(tx graph.Adder[string]) error {
+			if err := tx.Add("PUT:valA", keyA); err != nil {
+				return err
+			}
+
+			return errFail
+		})
+
+		assert.ErrorIs(t, err, errFail)
+		assert.Equal(t, expected, tree.View())
+	})
+}
@@ -13,35 +13,37 @@ type (
 		sealedEdge()
 	}
 
-	edgeConstant  string
-	edgeParameter []string
-	edgeValue     struct{}
-	edgeWildcard  struct{}
+	edgeConstant                string
+	edgeParameter[P comparable] []P
+	edgeValue                   struct{}
+	edgeWildcard                struct{}
 )
 
-func (edgeConstant) sealedEdge()  {}
-func (edgeParameter) sealedEdge() {}
-func (edgeValue) sealedEdge()     {}
-func (edgeWildcard) sealedEdge()  {}
+func (edgeConstant) sealedEdge()     {}
+func (edgeParameter[_]) sealedEdge() {}
+func (edgeValue) sealedEdge()        {}
+func (edgeWildcard) sealedEdge()     {}
 
 func (ep edgeValue) String() string    { return "value" }
 func (ew edgeWildcard) String() string { return "wild" }
 func (ec edgeConstant) String() string { return fmt.Sprintf("const(%s)", string(ec)) }
 
-func (ep edgeParameter) String() string {
-	strs := make([]string, len(ep))
-	for i, param := range ep {
-		strs[i] = fmt.Sprintf("%s", param)
+func formatParameters[P comparable](params []P) string {
+	strs := make([]string, len(params))
+	for i, param := range params {
+		strs[i] = fmt.Sprintf("%v", param)
 	}
-	return fmt.Sprintf("param(%s)", strings.Join(strs, ","))
+	return strings.Join(strs, ",")
 }
 
-func popEdge(keys []graph.Key) (edge, []graph.Key, error) {
+func (ep edgeParameter[P]) String() string { return fmt.Sprintf("param(%s)", formatParameters(ep)) }
+
+func popEdge[P comparable](keys []graph.Key) (edge, []graph.Key, error) {
 	if len(keys) < 1 {
 		return edgeValue{}, nil, nil
 	}
 
-	var paramEdge edgeParameter
+	var paramEdge edgeParameter[P]
 
 	for i, key := range keys {
 		if key == nil {
@@ -49,8 +51,8 @@ func popEdge(keys []graph.Key) (edge, []graph.Key, error) {
 		}
 
 		switch t := key.(type) {
-		case graph.KeyParameter:
-			paramEdge = append(paramEdge, string(t))
+		case graph.KeyParameter[P]:
+			paramEdge = append(paramEdge, t.Name)
 		case graph.KeyConstant:
 			if i == 0 {
 				return edgeConstant(t), keys[1:], nil
@@ -63,6 +65,8 @@ func popEdge(keys []graph.Key) (edge, []graph.Key, error) {
 			}
 
 			return paramEdge, keys[i:], nil
+		default:
+			return nil, nil, fmt.Errorf("%w: unexpected key type %T", graph.ErrInvalidKey, key)
 		}
 	}
 
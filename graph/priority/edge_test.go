@@ -14,9 +14,9 @@ func TestGraphPriorityPopEdge(t *testing.T) {
 		b = graph.KeyConstant("constB")
 		c = graph.KeyConstant("constB")
 
-		param1 = graph.KeyParameter("paramA")
-		param2 = graph.KeyParameter("paramB")
-		param3 = graph.KeyParameter("paramC")
+		param1 = graph.KeyParameter[string]{Name: "paramA"}
+		param2 = graph.KeyParameter[string]{Name: "paramB"}
+		param3 = graph.KeyParameter[string]{Name: "paramC"}
 
 		wild graph.KeyWildcard
 	)
@@ -42,7 +42,7 @@ func TestGraphPriorityPopEdge(t *testing.T) {
 		{
 			name:         "all parameter",
 			keys:         []graph.Key{param1, param2, param3},
-			expectedHead: edgeParameter{"paramA", "paramB", "paramC"},
+			expectedHead: edgeParameter[string]{"paramA", "paramB", "paramC"},
 			expectedTail: nil,
 		},
 		{
@@ -54,13 +54,13 @@ func TestGraphPriorityPopEdge(t *testing.T) {
 		{
 			name:         "parameter first (single)",
 			keys:         []graph.Key{param1, b, c},
-			expectedHead: edgeParameter{"paramA"},
+			expectedHead: edgeParameter[string]{"paramA"},
 			expectedTail: []graph.Key{b, c},
 		},
 		{
 			name:         "parameter first (multi)",
 			keys:         []graph.Key{param1, param2, c},
-			expectedHead: edgeParameter{"paramA", "paramB"},
+			expectedHead: edgeParameter[string]{"paramA", "paramB"},
 			expectedTail: []graph.Key{c},
 		},
 		{
@@ -73,7 +73,7 @@ func TestGraphPriorityPopEdge(t *testing.T) {
 
 	for _, subtest := range subtests {
 		t.Run(subtest.name, func(t *testing.T) {
-			head, tail, err := popEdge(subtest.keys)
+			head, tail, err := popEdge[string](subtest.keys)
 
 			require.NoError(t, err)
 
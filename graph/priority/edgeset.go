@@ -6,54 +6,80 @@ import (
 	"github.com/oligarch316/go-urlrouter/graph"
 )
 
-type edgeSetTerminal[V any] struct{ node *nodeValue[V] }
+// NOTE: Terminals hold values in registration order. More than one is only
+// possible when the tree allows values to coexist.
+type edgeSetTerminal[V any, P comparable] struct{ nodes []*nodeValue[V, P] }
 
-func (est *edgeSetTerminal[V]) add(state stateAdd[V]) error {
-	if est.node != nil {
-		return graph.DuplicateValueError[V]{ExistingValue: est.node.value}
+func (est *edgeSetTerminal[V, P]) add(state stateAdd[V, P]) error {
+	coexist := state.coexist
+	state.coexist = nil
+
+	for _, node := range est.nodes {
+		if coexist == nil || !coexist(node.value, state.value) {
+			return graph.DuplicateValueError[V]{ExistingValue: node.value}
+		}
 	}
 
-	est.node = &nodeValue[V]{state}
+	// NOTE: Terminals may be shared by a batch clone, so never append in place
+	nNodes := len(est.nodes)
+	est.nodes = append(est.nodes[:nNodes:nNodes], &nodeValue[V, P]{state})
 	return nil
 }
 
-func (est edgeSetTerminal[V]) result(parameterValues []string) *graph.SearchResult[V] {
-	if est.node == nil {
-		return nil
+func (est edgeSetTerminal[V, P]) search(tail []string, state stateSearch[V, P]) bool {
+	for _, node := range est.nodes {
+		result := node.result(state)
+		if len(tail) > 0 {
+			result.Tail = tail
+		}
+
+		// NOTE: A rejected value is skipped exactly as if it were never added
+		if node.guard != nil && !node.guard(state.context(), result) {
+			continue
+		}
+
+		result.Rank = state.rank()
+
+		if state.matches != nil {
+			*state.matches = append(*state.matches, Match[V, P]{Result: result, Sequence: node.sequence})
+			continue
+		}
+
+		if state.visitor.VisitSearch(result) {
+			return true
+		}
 	}
 
-	return est.node.result(parameterValues)
+	return false
 }
 
-func (est edgeSetTerminal[V]) walk(state stateWalk[V]) bool {
-	if est.node == nil {
-		return false
+func (est edgeSetTerminal[V, P]) walk(state stateWalk[V]) bool {
+	for _, node := range est.nodes {
+		if state.visitor.VisitWalk(node.value) {
+			return true
+		}
 	}
 
-	return state.visitor.VisitWalk(est.node.value)
+	return false
 }
 
-type edgeSetValue[V any] struct{ term edgeSetTerminal[V] }
+type edgeSetValue[V any, P comparable] struct{ term edgeSetTerminal[V, P] }
 
-func (esv *edgeSetValue[V]) add(e edgeValue, state stateAdd[V]) error {
+func (esv *edgeSetValue[V, P]) add(e edgeValue, state stateAdd[V, P]) error {
 	return esv.term.add(state)
 }
 
-func (esv edgeSetValue[V]) search(state stateSearch[V]) bool {
-	if result := esv.term.result(state.parameterValues); result != nil {
-		return state.visitor.VisitSearch(result)
-	}
-
-	return false
+func (esv edgeSetValue[V, P]) search(state stateSearch[V, P]) bool {
+	return esv.term.search(nil, state)
 }
 
-func (esv edgeSetValue[V]) walk(state stateWalk[V]) bool {
+func (esv edgeSetValue[V, P]) walk(state stateWalk[V]) bool {
 	return esv.term.walk(state)
 }
 
-type edgeSetWildcard[V any] struct{ term edgeSetTerminal[V] }
+type edgeSetWildcard[V any, P comparable] struct{ term edgeSetTerminal[V, P] }
 
-func (esw *edgeSetWildcard[V]) add(e edgeWildcard, path []graph.Key, state stateAdd[V]) error {
+func (esw *edgeSetWildcard[V, P]) add(e edgeWildcard, path []graph.Key, state stateAdd[V, P]) error {
 	if len(path) > 0 {
 		return graph.InvalidContinuationError{Continuation: path}
 	}
@@ -61,39 +87,31 @@ func (esw *edgeSetWildcard[V]) add(e edgeWildcard, path []graph.Key, state state
 	return esw.term.add(state)
 }
 
-func (esw edgeSetWildcard[V]) search(query []string, state stateSearch[V]) bool {
-	if result := esw.term.result(state.parameterValues); result != nil {
-		if len(query) > 0 {
-			result.Tail = query
-		}
-
-		return state.visitor.VisitSearch(result)
-	}
-
-	return false
+func (esw edgeSetWildcard[V, P]) search(query []string, state stateSearch[V, P]) bool {
+	return esw.term.search(query, state)
 }
 
-func (esw edgeSetWildcard[V]) walk(state stateWalk[V]) bool {
+func (esw edgeSetWildcard[V, P]) walk(state stateWalk[V]) bool {
 	return esw.term.walk(state)
 }
 
-type edgeSetConstant[V any] map[edgeConstant]*nodeConstant[V]
+type edgeSetConstant[V any, P comparable] map[edgeConstant]*nodeConstant[V, P]
 
-func (esc *edgeSetConstant[V]) add(e edgeConstant, path []graph.Key, state stateAdd[V]) error {
+func (esc *edgeSetConstant[V, P]) add(e edgeConstant, path []graph.Key, state stateAdd[V, P]) error {
 	if *esc == nil {
-		*esc = make(edgeSetConstant[V])
+		*esc = make(edgeSetConstant[V, P])
 	}
 
 	node, ok := (*esc)[e]
 	if !ok {
-		node = new(nodeConstant[V])
+		node = new(nodeConstant[V, P])
 		(*esc)[e] = node
 	}
 
 	return node.add(path, state)
 }
 
-func (esc edgeSetConstant[V]) search(query []string, state stateSearch[V]) bool {
+func (esc edgeSetConstant[V, P]) search(query []string, state stateSearch[V, P]) bool {
 	head, tail := edgeConstant(query[0]), query[1:]
 
 	if node, ok := esc[head]; ok {
@@ -103,7 +121,7 @@ func (esc edgeSetConstant[V]) search(query []string, state stateSearch[V]) bool
 	return false
 }
 
-func (esc edgeSetConstant[V]) walk(state stateWalk[V]) bool {
+func (esc edgeSetConstant[V, P]) walk(state stateWalk[V]) bool {
 	for _, node := range esc {
 		if node.walk(state) {
 			return true
@@ -113,13 +131,13 @@ func (esc edgeSetConstant[V]) walk(state stateWalk[V]) bool {
 	return false
 }
 
-type edgeSetParameter[V any] struct {
+type edgeSetParameter[V any, P comparable] struct {
 	nList sort.IntSlice
-	nMap  map[int]*nodeParameter[V]
+	nMap  map[int]*nodeParameter[V, P]
 }
 
-func (esp *edgeSetParameter[V]) createEntry(n int) *nodeParameter[V] {
-	node := new(nodeParameter[V])
+func (esp *edgeSetParameter[V, P]) createEntry(n int) *nodeParameter[V, P] {
+	node := new(nodeParameter[V, P])
 
 	esp.nMap[n] = node
 
@@ -130,9 +148,9 @@ func (esp *edgeSetParameter[V]) createEntry(n int) *nodeParameter[V] {
 	return node
 }
 
-func (esp *edgeSetParameter[V]) add(e edgeParameter, path []graph.Key, state stateAdd[V]) error {
+func (esp *edgeSetParameter[V, P]) add(e edgeParameter[P], path []graph.Key, state stateAdd[V, P]) error {
 	if esp.nMap == nil {
-		esp.nMap = make(map[int]*nodeParameter[V])
+		esp.nMap = make(map[int]*nodeParameter[V, P])
 	}
 
 	state.parameterKeys = append(state.parameterKeys, e...)
@@ -146,37 +164,47 @@ func (esp *edgeSetParameter[V]) add(e edgeParameter, path []graph.Key, state sta
 	return node.add(path, state)
 }
 
-func (esp edgeSetParameter[V]) search(query []string, state stateSearch[V]) bool {
+func (esp edgeSetParameter[V, P]) child(nParams int, query []string, state stateSearch[V, P]) (*nodeParameter[V, P], []string, stateSearch[V, P]) {
+	state.parameterValues = append(state.parameterValues, query[:nParams]...)
+	return esp.nMap[nParams], query[nParams:], state
+}
+
+// NOTE: As with http.ServeMux, a parameter never captures an empty segment, so
+// that "/users/:id" stays distinct from a trailing slash "/users/"
+func (esp edgeSetParameter[V, P]) capturable(query []string) int {
+	for i, seg := range query {
+		if seg == "" {
+			return i
+		}
+	}
+
+	return len(query)
+}
+
+func (esp edgeSetParameter[V, P]) search(query []string, state stateSearch[V, P]) bool {
+	if len(esp.nList) < 1 {
+		return false
+	}
+
 	var (
-		nSegs        = len(query)
-		wildSearches []func() bool
+		nMatch int
+		limit  = esp.capturable(query)
 	)
 
 	for _, nParams := range esp.nList {
-		if nParams > nSegs {
+		if nParams > limit {
 			break
 		}
 
-		var (
-			childNode  = esp.nMap[nParams]
-			childQuery = query[nParams:]
-			childState = stateSearch[V]{
-				parameterValues: append(state.parameterValues, query[:nParams]...),
-				visitor:         state.visitor,
-			}
-		)
-
-		if childNode.searchStatic(childQuery, childState) {
+		if node, childQuery, childState := esp.child(nParams, query, state); node.searchStatic(childQuery, childState) {
 			return true
 		}
 
-		wildSearches = append(wildSearches, func() bool {
-			return childNode.searchWild(childQuery, childState)
-		})
+		nMatch++
 	}
 
-	for i := len(wildSearches) - 1; i >= 0; i-- {
-		if wildSearches[i]() {
+	for i := nMatch - 1; i >= 0; i-- {
+		if node, childQuery, childState := esp.child(esp.nList[i], query, state); node.searchWild(childQuery, childState) {
 			return true
 		}
 	}
@@ -184,7 +212,7 @@ func (esp edgeSetParameter[V]) search(query []string, state stateSearch[V]) bool
 	return false
 }
 
-func (esp edgeSetParameter[V]) walk(state stateWalk[V]) bool {
+func (esp edgeSetParameter[V, P]) walk(state stateWalk[V]) bool {
 	for _, node := range esp.nMap {
 		if node.walk(state) {
 			return true
@@ -0,0 +1,25 @@
+package priority_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+)
+
+// NOTE: Keys shared by the tree fixtures of this package
+var (
+	keyA      = graph.KeyConstant("a")
+	keyB      = graph.KeyConstant("b")
+	keyParam1 = graph.KeyParameter[string]{Name: "param1"}
+	keyParam2 = graph.KeyParameter[string]{Name: "param2"}
+	keyWild   = graph.KeyWildcard{}
+)
+
+func fixtureTree(t testing.TB, tree *priority.Tree[string, string], paths ...graphtest.PathItem) *priority.Tree[string, string] {
+	t.Helper()
+
+	graphtest.AddPaths(t, graphtest.PriorityTree{Tree: tree}, paths...)
+	return tree
+}
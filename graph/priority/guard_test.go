@@ -0,0 +1,124 @@
+package priority_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type guardMethodKey struct{}
+
+func guardMethod(method string) graph.Guard[string, string] {
+	return func(ctx context.Context, _ *graph.SearchResult[string, string]) bool {
+		return ctx.Value(guardMethodKey{}) == method
+	}
+}
+
+func guardNumeric(_ context.Context, result *graph.SearchResult[string, string]) bool {
+	for _, r := range result.Param(0) {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func guardTree(t *testing.T) *priority.Tree[string, string] {
+	return fixtureTree(t, new(priority.Tree[string, string]),
+		graphtest.GuardedPath("valAPost", guardMethod("POST"), keyA),
+		graphtest.GuardedPath("valParamNumeric", guardNumeric, keyParam1),
+		graphtest.Path("valWild", keyWild),
+	)
+}
+
+func TestGraphPriorityGuard(t *testing.T) {
+	var (
+		tree = guardTree(t)
+		post = context.WithValue(context.Background(), guardMethodKey{}, "POST")
+	)
+
+	searchContext := func(ctx context.Context, query ...string) []string {
+		visitor := new(search.VisitorAll[string, string])
+		require.NoError(t, tree.SearchContext(ctx, visitor, query...))
+		return resultValues(visitor.Results)
+	}
+
+	subtests := []struct {
+		name     string
+		ctx      context.Context
+		query    []string
+		expected []string
+	}{
+		{name: "accepted", ctx: post, query: []string{"a"}, expected: []string{"valAPost", "valWild"}},
+		{name: "rejected context", ctx: context.Background(), query: []string{"a"}, expected: []string{"valWild"}},
+		{name: "accepted parameter", ctx: post, query: []string{"123"}, expected: []string{"valParamNumeric", "valWild"}},
+		{name: "rejected parameter", ctx: post, query: []string{"abc"}, expected: []string{"valWild"}},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			assert.Equal(t, st.expected, searchContext(st.ctx, st.query...))
+		})
+	}
+
+	t.Run("background", func(t *testing.T) {
+		results := search.All[string, string](tree, "a")
+		assert.Equal(t, []string{"valWild"}, resultValues(results))
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, 0, results[0].Rank)
+		}
+	})
+
+	t.Run("buffered", func(t *testing.T) {
+		buffer := new(graph.SearchBuffer[string, string])
+
+		if result := search.FirstBuffered[string, string](tree, buffer, "a"); assert.NotNil(t, result) {
+			assert.Equal(t, "valWild", result.Value)
+			assert.Equal(t, 1, buffer.Count)
+		}
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		policyTree := *tree
+		policyTree.Policy = priority.PolicyLastMatch[string, string]{}
+
+		visitor := new(search.VisitorAll[string, string])
+		require.NoError(t, policyTree.SearchContext(post, visitor, "a"))
+		assert.Equal(t, []string{"valWild", "valAPost"}, resultValues(visitor.Results))
+	})
+}
+
+func TestGraphPriorityGuardEncode(t *testing.T) {
+	tree := guardTree(t)
+
+	t.Run("view", func(t *testing.T) {
+		var guarded []bool
+		for _, terminal := range tree.View().Terminals() {
+			guarded = append(guarded, terminal.Guarded)
+		}
+
+		assert.Equal(t, []bool{true, true, false}, guarded)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		_, err := priority.MarshalJSON[string, string](*tree, graph.JSONValueCodec[string]{})
+		assert.ErrorIs(t, err, priority.ErrEncodeGuard)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := priority.WriteSnapshot[string, string](&buf, *tree, priority.SnapshotCodec[string, string]{})
+		assert.ErrorIs(t, err, priority.ErrEncodeGuard)
+	})
+}
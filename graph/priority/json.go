@@ -0,0 +1,113 @@
+package priority
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+var ErrEncodeGuard = errors.New("cannot encode guarded value")
+
+// NOTE: Sequence records registration order, so that policies survive a round
+// trip. Routes without one are sequenced in the order given.
+type jsonRoute[P comparable] struct {
+	Path     graph.KeyPath[P] `json:"path"`
+	Sequence *int             `json:"sequence,omitempty"`
+	Value    json.RawMessage  `json:"value"`
+}
+
+type jsonTree[P comparable] struct {
+	Routes []jsonRoute[P] `json:"routes"`
+}
+
+// NOTE: Encoded values are embedded as-is, so codec output must be valid JSON.
+func MarshalJSON[V any, P comparable](tree Tree[V, P], codec graph.ValueCodec[V]) ([]byte, error) {
+	var (
+		terminals = tree.View().Terminals()
+		raw       = jsonTree[P]{Routes: make([]jsonRoute[P], len(terminals))}
+	)
+
+	for i, terminal := range terminals {
+		if terminal.Guarded {
+			return nil, fmt.Errorf("%w: %s", ErrEncodeGuard, graph.FormatPath(terminal.Value, terminal.Path...))
+		}
+
+		data, err := codec.EncodeValue(terminal.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		sequence := terminal.Sequence
+		raw.Routes[i] = jsonRoute[P]{Path: terminal.Path, Sequence: &sequence, Value: data}
+	}
+
+	return json.Marshal(raw)
+}
+
+// LoadJSON replaces the routes of into with those in data, keeping its Coexist
+// and Policy. On error, into is left unchanged.
+func LoadJSON[V any, P comparable](data []byte, codec graph.ValueCodec[V], into *Tree[V, P]) error {
+	var raw jsonTree[P]
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	res := &Tree[V, P]{Coexist: into.Coexist}
+
+	for i, route := range raw.Routes {
+		value, err := codec.DecodeValue(route.Value)
+		if err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+
+		sequence := res.sequence
+		if route.Sequence != nil {
+			sequence = *route.Sequence
+		}
+
+		if err := res.addSequence(value, nil, sequence, route.Path); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+	}
+
+	into.root, into.sequence = res.root, res.sequence
+	return nil
+}
+
+type JSONTree[V any, P comparable] struct {
+	Codec graph.ValueCodec[V]
+	Tree  *Tree[V, P]
+}
+
+func (jt JSONTree[V, P]) codec() graph.ValueCodec[V] {
+	if jt.Codec == nil {
+		return graph.JSONValueCodec[V]{}
+	}
+
+	return jt.Codec
+}
+
+func (jt JSONTree[V, P]) MarshalJSON() ([]byte, error) {
+	if jt.Tree == nil {
+		return MarshalJSON(Tree[V, P]{}, jt.codec())
+	}
+
+	return MarshalJSON(*jt.Tree, jt.codec())
+}
+
+// NOTE: A preset Tree is loaded into, so that its Coexist and Policy apply.
+func (jt *JSONTree[V, P]) UnmarshalJSON(data []byte) error {
+	tree := jt.Tree
+	if tree == nil {
+		tree = new(Tree[V, P])
+	}
+
+	if err := LoadJSON(data, jt.codec(), tree); err != nil {
+		return err
+	}
+
+	jt.Tree = tree
+	return nil
+}
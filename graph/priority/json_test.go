@@ -0,0 +1,54 @@
+package priority_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphPriorityJSON(t *testing.T) {
+	original := fixtureTree(t, new(priority.Tree[string, string]),
+		graphtest.Path("valRoot"),
+		graphtest.Path("valA", keyA),
+		graphtest.Path("valParamA", keyParam1, keyA),
+		graphtest.Path("valWild", keyWild),
+	)
+
+	data, err := json.Marshal(priority.JSONTree[string, string]{Tree: original})
+	require.NoError(t, err)
+
+	var loaded priority.JSONTree[string, string]
+	require.NoError(t, json.Unmarshal(data, &loaded))
+
+	assert.Equal(t, original.View(), loaded.Tree.View())
+
+	actual := search.First[string, string](loaded.Tree, "x", "a")
+	require.NotNil(t, actual)
+	assert.Equal(t, "valParamA", actual.Value)
+	assert.Equal(t, map[string]string{"param1": "x"}, actual.Parameters)
+}
+
+func TestGraphPriorityJSONDuplicate(t *testing.T) {
+	data := `{"routes":[
+		{"path":[{"kind":"constant","name":"a"}],"value":"val1"},
+		{"path":[{"kind":"constant","name":"a"}],"value":"val2"}
+	]}`
+
+	tree := fixtureTree(t, new(priority.Tree[string, string]), graphtest.Path("valB", keyB))
+
+	err := priority.LoadJSON([]byte(data), graph.JSONValueCodec[string]{}, tree)
+	assert.ErrorAs(t, err, new(graph.DuplicateValueError[string]))
+	assert.Equal(t, "duplicate value", errors.Unwrap(err).Error())
+
+	actual := search.First[string, string](*tree, "b")
+	if assert.NotNil(t, actual, "check unchanged") {
+		assert.Equal(t, "valB", actual.Value)
+	}
+}
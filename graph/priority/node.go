@@ -16,31 +16,78 @@ func internalErrorf(format string, a ...interface{}) internalError {
 func (ie internalError) Error() string        { return string(ie) }
 func (ie internalError) Is(target error) bool { return target == graph.ErrInternal }
 
-type nodeValue[V any] struct{ stateAdd[V] }
+type nodeValue[V any, P comparable] struct{ stateAdd[V, P] }
 
-func (nv nodeValue[V]) result(parameterValues []string) *graph.SearchResult[V] {
-	res := &graph.SearchResult[V]{Value: nv.value}
+func (nv nodeValue[V, P]) resultPath() []graph.Key {
+	nKeys := len(nv.path)
+	return nv.path[:nKeys:nKeys]
+}
+
+func (nv nodeValue[V, P]) resultParameterNames() []P {
+	nParams := len(nv.parameterKeys)
+	return nv.parameterKeys[:nParams:nParams]
+}
+
+func (nv nodeValue[V, P]) result(state stateSearch[V, P]) *graph.SearchResult[V, P] {
+	if state.buffer != nil {
+		return nv.resultBuffered(state)
+	}
+
+	res := &graph.SearchResult[V, P]{Path: nv.resultPath(), Value: nv.value}
 
-	if len(nv.parameterKeys) > 0 {
-		res.Parameters = make(map[string]string)
+	if nParams := len(nv.parameterKeys); nParams > 0 {
+		res.Parameters = make(map[P]string, nParams)
+		res.ParameterNames = nv.resultParameterNames()
 
-		for i, key := range nv.parameterKeys {
-			res.Parameters[key] = parameterValues[i]
+		// NOTE: state.parameterValues is shared with sibling branches of the search
+		res.ParameterValues = append([]string(nil), state.parameterValues[:nParams]...)
+
+		for i, key := range res.ParameterNames {
+			res.Parameters[key] = res.ParameterValues[i]
 		}
 	}
 
 	return res
 }
 
-type nodeConstant[V any] struct {
-	constantEdges  edgeSetConstant[V]
-	parameterEdges edgeSetParameter[V]
-	valueEdges     edgeSetValue[V]
-	wildcardEdges  edgeSetWildcard[V]
+func (nv nodeValue[V, P]) resultBuffered(state stateSearch[V, P]) *graph.SearchResult[V, P] {
+	var (
+		res             = &state.buffer.Result
+		parameterValues = state.parameterValues
+	)
+
+	res.Path, res.Tail, res.Value = nv.resultPath(), nil, nv.value
+	res.ParameterNames, res.ParameterValues = nil, nil
+
+	for key := range res.Parameters {
+		delete(res.Parameters, key)
+	}
+
+	if len(nv.parameterKeys) > 0 && res.Parameters == nil {
+		res.Parameters = make(map[P]string, len(nv.parameterKeys))
+	}
+
+	for i, key := range nv.parameterKeys {
+		res.Parameters[key] = parameterValues[i]
+	}
+
+	if nParams := len(nv.parameterKeys); nParams > 0 {
+		res.ParameterNames = nv.resultParameterNames()
+		res.ParameterValues = parameterValues[:nParams:nParams]
+	}
+
+	return res
 }
 
-func (nc *nodeConstant[V]) add(path []graph.Key, state stateAdd[V]) error {
-	head, tail, err := popEdge(path)
+type nodeConstant[V any, P comparable] struct {
+	constantEdges  edgeSetConstant[V, P]
+	parameterEdges edgeSetParameter[V, P]
+	valueEdges     edgeSetValue[V, P]
+	wildcardEdges  edgeSetWildcard[V, P]
+}
+
+func (nc *nodeConstant[V, P]) add(path []graph.Key, state stateAdd[V, P]) error {
+	head, tail, err := popEdge[P](path)
 	if err != nil {
 		return err
 	}
@@ -50,7 +97,7 @@ func (nc *nodeConstant[V]) add(path []graph.Key, state stateAdd[V]) error {
 		return nc.valueEdges.add(e, state)
 	case edgeConstant:
 		return nc.constantEdges.add(e, tail, state)
-	case edgeParameter:
+	case edgeParameter[P]:
 		return nc.parameterEdges.add(e, tail, state)
 	case edgeWildcard:
 		return nc.wildcardEdges.add(e, tail, state)
@@ -59,7 +106,11 @@ func (nc *nodeConstant[V]) add(path []graph.Key, state stateAdd[V]) error {
 	return internalErrorf("constant node: invalid edge type %T: %s", head, head)
 }
 
-func (nc nodeConstant[V]) search(query []string, state stateSearch[V]) bool {
+func (nc nodeConstant[V, P]) search(query []string, state stateSearch[V, P]) bool {
+	if state.control.halt() {
+		return true
+	}
+
 	if len(query) < 1 {
 		if nc.valueEdges.search(state) {
 			return true
@@ -79,7 +130,7 @@ func (nc nodeConstant[V]) search(query []string, state stateSearch[V]) bool {
 	return nc.wildcardEdges.search(query, state)
 }
 
-func (nc nodeConstant[V]) walk(state stateWalk[V]) bool {
+func (nc nodeConstant[V, P]) walk(state stateWalk[V]) bool {
 	if nc.valueEdges.walk(state) {
 		return true
 	}
@@ -95,14 +146,14 @@ func (nc nodeConstant[V]) walk(state stateWalk[V]) bool {
 	return nc.wildcardEdges.walk(state)
 }
 
-type nodeParameter[V any] struct {
-	constantEdges edgeSetConstant[V]
-	valueEdges    edgeSetValue[V]
-	wildcardEdges edgeSetWildcard[V]
+type nodeParameter[V any, P comparable] struct {
+	constantEdges edgeSetConstant[V, P]
+	valueEdges    edgeSetValue[V, P]
+	wildcardEdges edgeSetWildcard[V, P]
 }
 
-func (np *nodeParameter[V]) add(path []graph.Key, state stateAdd[V]) error {
-	head, tail, err := popEdge(path)
+func (np *nodeParameter[V, P]) add(path []graph.Key, state stateAdd[V, P]) error {
+	head, tail, err := popEdge[P](path)
 	if err != nil {
 		return err
 	}
@@ -119,7 +170,11 @@ func (np *nodeParameter[V]) add(path []graph.Key, state stateAdd[V]) error {
 	return internalErrorf("parameter node: invalid edge type %T: %s", head, head)
 }
 
-func (np nodeParameter[V]) searchStatic(query []string, state stateSearch[V]) bool {
+func (np nodeParameter[V, P]) searchStatic(query []string, state stateSearch[V, P]) bool {
+	if state.control.halt() {
+		return true
+	}
+
 	if len(query) < 1 {
 		return np.valueEdges.search(state)
 	}
@@ -127,11 +182,15 @@ func (np nodeParameter[V]) searchStatic(query []string, state stateSearch[V]) bo
 	return np.constantEdges.search(query, state)
 }
 
-func (np nodeParameter[V]) searchWild(query []string, state stateSearch[V]) bool {
+func (np nodeParameter[V, P]) searchWild(query []string, state stateSearch[V, P]) bool {
+	if state.control.halt() {
+		return true
+	}
+
 	return np.wildcardEdges.search(query, state)
 }
 
-func (np nodeParameter[V]) walk(state stateWalk[V]) bool {
+func (np nodeParameter[V, P]) walk(state stateWalk[V]) bool {
 	if np.valueEdges.walk(state) {
 		return true
 	}
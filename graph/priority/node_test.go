@@ -9,9 +9,9 @@ import (
 
 func TestGraphPriorityInternalError(t *testing.T) {
 	var (
-		node  nodeParameter[string]
-		keys  = []graph.Key{graph.KeyParameter("someParam")}
-		state = stateAdd[string]{value: "someVal"}
+		node  nodeParameter[string, string]
+		keys  = []graph.Key{graph.KeyParameter[string]{Name: "someParam"}}
+		state = stateAdd[string, string]{value: "someVal"}
 	)
 
 	assert.ErrorIs(t, node.add(keys, state), graph.ErrInternal)
@@ -0,0 +1,112 @@
+package priority
+
+import (
+	"sort"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+// Match is a search result along with the order in which its value was added.
+type Match[V any, P comparable] struct {
+	Result   *graph.SearchResult[V, P]
+	Sequence int
+}
+
+// Policy reorders every match for a query before any is visited. Matches are
+// given in default priority order, that being constant > parameter (ascending
+// arity, static before wild) > wildcard at each segment.
+type Policy[V any, P comparable] interface {
+	Order([]Match[V, P])
+}
+
+type PolicyFunc[V any, P comparable] func([]Match[V, P])
+
+func (pf PolicyFunc[V, P]) Order(matches []Match[V, P]) { pf(matches) }
+
+// PolicyRegistration orders matches by the order in which they were added.
+type PolicyRegistration[V any, P comparable] struct{}
+
+func (PolicyRegistration[V, P]) Order(matches []Match[V, P]) {
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Sequence < matches[j].Sequence })
+}
+
+// PolicyLastMatch orders the most recently added matches first, in the manner
+// of CODEOWNERS or gitignore files.
+type PolicyLastMatch[V any, P comparable] struct{}
+
+func (PolicyLastMatch[V, P]) Order(matches []Match[V, P]) {
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Sequence > matches[j].Sequence })
+}
+
+// PolicyLongestPrefix orders matches by the number of constants leading their
+// path, longest first, and otherwise by the order in which they were added. Where
+// default priority prefers a parameter to a wildcard after an equal literal
+// prefix, this policy prefers whichever was added first.
+type PolicyLongestPrefix[V any, P comparable] struct{}
+
+func literalPrefix(path []graph.Key) int {
+	for i, key := range path {
+		if _, ok := key.(graph.KeyConstant); !ok {
+			return i
+		}
+	}
+
+	return len(path)
+}
+
+func (PolicyLongestPrefix[V, P]) Order(matches []Match[V, P]) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		iPrefix, jPrefix := literalPrefix(matches[i].Result.Path), literalPrefix(matches[j].Result.Path)
+		if iPrefix != jPrefix {
+			return iPrefix > jPrefix
+		}
+
+		return matches[i].Sequence < matches[j].Sequence
+	})
+}
+
+// PolicySpecificity orders matches by graph.Specificity regardless of where
+// their constants occur, falling back to default priority.
+type PolicySpecificity[V any, P comparable] struct{}
+
+func (PolicySpecificity[V, P]) Order(matches []Match[V, P]) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Result.Specificity().Compare(matches[j].Result.Specificity()) > 0
+	})
+}
+
+func (t Tree[V, P]) searchPolicy(buffer *graph.SearchBuffer[V, P], control *searchControl, searcher graph.Searcher[V, P], query []string) (visited int) {
+	var matches []Match[V, P]
+
+	state := stateSearch[V, P]{
+		control: control,
+		matches: &matches,
+		ranks:   new(int),
+		visitor: searcher,
+	}
+
+	t.root.search(query, state)
+
+	// NOTE: An incomplete set of matches cannot be reliably ordered
+	if control != nil && control.err != nil {
+		return 0
+	}
+
+	t.Policy.Order(matches)
+
+	for i, match := range matches {
+		result := match.Result
+		result.Rank = i
+
+		if buffer != nil {
+			buffer.Result = *result
+			result = &buffer.Result
+		}
+
+		if searcher.VisitSearch(result) {
+			return i + 1
+		}
+	}
+
+	return len(matches)
+}
@@ -0,0 +1,188 @@
+package priority_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policyTree(t *testing.T, policy priority.Policy[string, string]) *priority.Tree[string, string] {
+	return fixtureTree(t, &priority.Tree[string, string]{Policy: policy},
+		graphtest.Path("valWild", keyWild),
+		graphtest.Path("valParamB", keyParam1, keyB),
+		graphtest.Path("valAB", keyA, keyB),
+		graphtest.Path("valAWild", keyA, keyWild),
+	)
+}
+
+func resultValues(results []*graph.SearchResult[string, string]) []string {
+	res := make([]string, len(results))
+	for i, result := range results {
+		res[i] = result.Value
+	}
+	return res
+}
+
+func TestGraphPriorityPolicy(t *testing.T) {
+	query := []string{"a", "b"}
+
+	subtests := []struct {
+		name     string
+		policy   priority.Policy[string, string]
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"valAB", "valAWild", "valParamB", "valWild"},
+		},
+		{
+			name:     "registration",
+			policy:   priority.PolicyRegistration[string, string]{},
+			expected: []string{"valWild", "valParamB", "valAB", "valAWild"},
+		},
+		{
+			name:     "last match",
+			policy:   priority.PolicyLastMatch[string, string]{},
+			expected: []string{"valAWild", "valAB", "valParamB", "valWild"},
+		},
+		{
+			name:     "specificity",
+			policy:   priority.PolicySpecificity[string, string]{},
+			expected: []string{"valAB", "valParamB", "valAWild", "valWild"},
+		},
+		{
+			name: "func",
+			policy: priority.PolicyFunc[string, string](func(matches []priority.Match[string, string]) {
+				matches[0], matches[len(matches)-1] = matches[len(matches)-1], matches[0]
+			}),
+			expected: []string{"valWild", "valAWild", "valParamB", "valAB"},
+		},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			tree := policyTree(t, st.policy)
+			results := search.All[string, string](tree, query...)
+
+			assert.Equal(t, st.expected, resultValues(results))
+
+			for i, result := range results {
+				assert.Equal(t, i, result.Rank, "check rank %d", i)
+			}
+
+			first := search.First[string, string](tree, query...)
+			if assert.NotNil(t, first) {
+				assert.Equal(t, st.expected[0], first.Value)
+			}
+
+			buffer := new(graph.SearchBuffer[string, string])
+			if buffered := search.FirstBuffered[string, string](tree, buffer, query...); assert.NotNil(t, buffered) {
+				assert.Equal(t, st.expected[0], buffered.Value)
+			}
+		})
+	}
+}
+
+func TestGraphPriorityPolicyLongestPrefix(t *testing.T) {
+	query := []string{"a", "b"}
+
+	subtests := []struct {
+		name     string
+		policy   priority.Policy[string, string]
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"valAB", "valAParam", "valAWild", "valParamB", "valWild"},
+		},
+		{
+			name:     "longest prefix",
+			policy:   priority.PolicyLongestPrefix[string, string]{},
+			expected: []string{"valAB", "valAWild", "valAParam", "valWild", "valParamB"},
+		},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			tree := fixtureTree(t, &priority.Tree[string, string]{Policy: st.policy},
+				graphtest.Path("valWild", keyWild),
+				graphtest.Path("valAWild", keyA, keyWild),
+				graphtest.Path("valParamB", keyParam1, keyB),
+				graphtest.Path("valAParam", keyA, keyParam1),
+				graphtest.Path("valAB", keyA, keyB),
+			)
+
+			actual := resultValues(search.All[string, string](tree, query...))
+			assert.Equal(t, st.expected, actual, graphtest.Info(graphtest.Query(query...), graphtest.PriorityTree{Tree: tree}))
+		})
+	}
+}
+
+func TestGraphPriorityPolicyContext(t *testing.T) {
+	var (
+		tree    = policyTree(t, priority.PolicyLastMatch[string, string]{})
+		visitor = new(search.VisitorAll[string, string])
+	)
+
+	err := tree.SearchBudget(context.Background(), 1, visitor, "a", "b")
+	assert.ErrorIs(t, err, graph.ErrSearchBudget)
+	assert.Empty(t, visitor.Results)
+}
+
+func TestGraphPriorityPolicySnapshot(t *testing.T) {
+	var (
+		original = policyTree(t, priority.PolicyRegistration[string, string]{})
+		codec    = priority.SnapshotCodec[string, string]{}
+		buf      bytes.Buffer
+	)
+
+	require.NoError(t, priority.WriteSnapshot[string, string](&buf, *original, codec))
+
+	loaded := &priority.Tree[string, string]{Policy: original.Policy}
+	require.NoError(t, priority.ReadSnapshot(&buf, codec, loaded))
+
+	fixtureTree(t, loaded, graphtest.Path("valA", keyA))
+	fixtureTree(t, original, graphtest.Path("valA", keyA))
+
+	for _, query := range [][]string{{"a", "b"}, {"a"}} {
+		var (
+			expected = resultValues(search.All[string, string](original, query...))
+			actual   = resultValues(search.All[string, string](loaded, query...))
+		)
+
+		assert.Equal(t, expected, actual, graph.FormatQuery(query...))
+	}
+}
+
+func TestGraphPriorityPolicyJSON(t *testing.T) {
+	original := policyTree(t, priority.PolicyRegistration[string, string]{})
+
+	data, err := priority.MarshalJSON[string, string](*original, graph.JSONValueCodec[string]{})
+	require.NoError(t, err)
+
+	loaded := &priority.Tree[string, string]{Policy: original.Policy}
+	require.NoError(t, priority.LoadJSON(data, graph.JSONValueCodec[string]{}, loaded))
+
+	fixtureTree(t, loaded, graphtest.Path("valA", keyA))
+	fixtureTree(t, original, graphtest.Path("valA", keyA))
+
+	for _, query := range [][]string{{"a", "b"}, {"a"}, {"b"}} {
+		var (
+			expected = resultValues(search.All[string, string](original, query...))
+			actual   = resultValues(search.All[string, string](loaded, query...))
+		)
+
+		assert.Equal(t, expected, actual, graph.FormatQuery(query...))
+	}
+}
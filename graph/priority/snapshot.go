@@ -0,0 +1,438 @@
+package priority
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+const (
+	snapshotMagic   = "URTS"
+	snapshotVersion = 4
+
+	snapshotHeaderLen  = len(snapshotMagic) + 2
+	snapshotTrailerLen = 4
+)
+
+var (
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+	ErrSnapshotFormat   = errors.New("invalid snapshot format")
+	ErrSnapshotVersion  = errors.New("unsupported snapshot version")
+)
+
+type SnapshotCodec[V any, P comparable] struct {
+	Parameters graph.ValueCodec[P]
+	Values     graph.ValueCodec[V]
+}
+
+func (sc SnapshotCodec[V, P]) withDefaults() SnapshotCodec[V, P] {
+	if sc.Parameters == nil {
+		sc.Parameters = graph.JSONValueCodec[P]{}
+	}
+
+	if sc.Values == nil {
+		sc.Values = graph.JSONValueCodec[V]{}
+	}
+
+	return sc
+}
+
+// NOTE: Snapshot layout is
+// magic (4) | version (uint16) | payload | crc32 of payload (uint32)
+// where the payload encodes nodes depth first, bypassing Add entirely on load.
+// Terminals record their registration sequence so that policies survive a
+// round trip.
+
+type snapshotWriter[V any, P comparable] struct {
+	buf   bytes.Buffer
+	codec SnapshotCodec[V, P]
+}
+
+func (sw *snapshotWriter[V, P]) uvarint(n int) {
+	var tmp [binary.MaxVarintLen64]byte
+	sw.buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(n))])
+}
+
+func (sw *snapshotWriter[V, P]) bytes(data []byte) {
+	sw.uvarint(len(data))
+	sw.buf.Write(data)
+}
+
+func (sw *snapshotWriter[V, P]) string(s string) {
+	sw.uvarint(len(s))
+	sw.buf.WriteString(s)
+}
+
+func (sw *snapshotWriter[V, P]) value(node *nodeValue[V, P]) error {
+	if node.guard != nil {
+		return fmt.Errorf("%w: %s", ErrEncodeGuard, graph.FormatPath(node.value, node.path...))
+	}
+
+	data, err := sw.codec.Values.EncodeValue(node.value)
+	if err != nil {
+		return err
+	}
+
+	sw.uvarint(node.sequence)
+	sw.uvarint(len(node.parameterKeys))
+	for _, key := range node.parameterKeys {
+		keyData, err := sw.codec.Parameters.EncodeValue(key)
+		if err != nil {
+			return err
+		}
+
+		sw.bytes(keyData)
+	}
+	sw.bytes(data)
+
+	return nil
+}
+
+func (sw *snapshotWriter[V, P]) terminal(term edgeSetTerminal[V, P]) error {
+	sw.uvarint(len(term.nodes))
+	for _, node := range term.nodes {
+		if err := sw.value(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sw *snapshotWriter[V, P]) constants(esc edgeSetConstant[V, P]) error {
+	names := make([]string, 0, len(esc))
+	for e := range esc {
+		names = append(names, string(e))
+	}
+	sort.Strings(names)
+
+	sw.uvarint(len(names))
+	for _, name := range names {
+		sw.string(name)
+
+		if err := sw.nodeConstant(esc[edgeConstant(name)]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sw *snapshotWriter[V, P]) parameters(esp edgeSetParameter[V, P]) error {
+	sw.uvarint(len(esp.nList))
+	for _, n := range esp.nList {
+		sw.uvarint(n)
+
+		if err := sw.nodeParameter(esp.nMap[n]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sw *snapshotWriter[V, P]) nodeConstant(node *nodeConstant[V, P]) error {
+	if err := sw.terminal(node.valueEdges.term); err != nil {
+		return err
+	}
+
+	if err := sw.constants(node.constantEdges); err != nil {
+		return err
+	}
+
+	if err := sw.parameters(node.parameterEdges); err != nil {
+		return err
+	}
+
+	return sw.terminal(node.wildcardEdges.term)
+}
+
+func (sw *snapshotWriter[V, P]) nodeParameter(node *nodeParameter[V, P]) error {
+	if err := sw.terminal(node.valueEdges.term); err != nil {
+		return err
+	}
+
+	if err := sw.constants(node.constantEdges); err != nil {
+		return err
+	}
+
+	return sw.terminal(node.wildcardEdges.term)
+}
+
+// NOTE: Key paths are not stored, but rebuilt from the edges traversed on load.
+type snapshotReader[V any, P comparable] struct {
+	buf   *bytes.Reader
+	codec SnapshotCodec[V, P]
+	path  *viewBuilder
+	tree  *Tree[V, P]
+}
+
+func (sr snapshotReader[V, P]) uvarint() (int, error) {
+	n, err := binary.ReadUvarint(sr.buf)
+	if err != nil || n > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("%w: bad integer", ErrSnapshotFormat)
+	}
+
+	return int(n), nil
+}
+
+// Every counted item occupies at least one byte, so no length can exceed the remaining data
+func (sr snapshotReader[V, P]) length() (int, error) {
+	n, err := sr.uvarint()
+	if err == nil && n > sr.buf.Len() {
+		err = fmt.Errorf("%w: bad length", ErrSnapshotFormat)
+	}
+
+	return n, err
+}
+
+func (sr snapshotReader[V, P]) bytes() ([]byte, error) {
+	n, err := sr.length()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, n)
+	if _, err := io.ReadFull(sr.buf, res); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotFormat, err)
+	}
+
+	return res, nil
+}
+
+func (sr snapshotReader[V, P]) string() (string, error) {
+	data, err := sr.bytes()
+	return string(data), err
+}
+
+func (sr snapshotReader[V, P]) terminal(term *edgeSetTerminal[V, P], tail ...graph.Key) error {
+	n, err := sr.length()
+	if err != nil || n == 0 {
+		return err
+	}
+
+	term.nodes = make([]*nodeValue[V, P], n)
+
+	for i := range term.nodes {
+		if term.nodes[i], err = sr.value(tail); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sr snapshotReader[V, P]) value(tail []graph.Key) (*nodeValue[V, P], error) {
+	sequence, err := sr.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if sequence >= sr.tree.sequence {
+		sr.tree.sequence = sequence + 1
+	}
+
+	nKeys, err := sr.length()
+	if err != nil {
+		return nil, err
+	}
+
+	if nKeys != sr.path.parameters() {
+		return nil, fmt.Errorf("%w: bad parameter count %d", ErrSnapshotFormat, nKeys)
+	}
+
+	state := stateAdd[V, P]{sequence: sequence}
+
+	if nKeys > 0 {
+		state.parameterKeys = make([]P, nKeys)
+	}
+
+	for i := range state.parameterKeys {
+		keyData, err := sr.bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		if state.parameterKeys[i], err = sr.codec.Parameters.DecodeValue(keyData); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := sr.bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if state.value, err = sr.codec.Values.DecodeValue(data); err != nil {
+		return nil, err
+	}
+
+	state.path = terminalPath(sr.path, state.parameterKeys, tail...)
+	return &nodeValue[V, P]{state}, nil
+}
+
+func (sr snapshotReader[V, P]) constants(esc *edgeSetConstant[V, P]) error {
+	n, err := sr.length()
+	if err != nil || n == 0 {
+		return err
+	}
+
+	*esc = make(edgeSetConstant[V, P], n)
+
+	for i := 0; i < n; i++ {
+		name, err := sr.string()
+		if err != nil {
+			return err
+		}
+
+		node := new(nodeConstant[V, P])
+
+		sr.path.push(graph.KeyConstant(name))
+		if err := sr.nodeConstant(node); err != nil {
+			return err
+		}
+		sr.path.pop(1)
+
+		(*esc)[edgeConstant(name)] = node
+	}
+
+	return nil
+}
+
+func (sr snapshotReader[V, P]) parameters(esp *edgeSetParameter[V, P]) error {
+	n, err := sr.length()
+	if err != nil || n == 0 {
+		return err
+	}
+
+	esp.nMap = make(map[int]*nodeParameter[V, P], n)
+
+	for i := 0; i < n; i++ {
+		// Each parameter of this arity is encoded again in every terminal below
+		arity, err := sr.length()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := esp.nMap[arity]; ok || arity == 0 {
+			return fmt.Errorf("%w: bad parameter arity %d", ErrSnapshotFormat, arity)
+		}
+
+		node := esp.createEntry(arity)
+
+		sr.path.push(make([]graph.Key, arity)...)
+		if err := sr.nodeParameter(node); err != nil {
+			return err
+		}
+		sr.path.pop(arity)
+	}
+
+	return nil
+}
+
+func (sr snapshotReader[V, P]) nodeConstant(node *nodeConstant[V, P]) error {
+	if err := sr.terminal(&node.valueEdges.term); err != nil {
+		return err
+	}
+
+	if err := sr.constants(&node.constantEdges); err != nil {
+		return err
+	}
+
+	if err := sr.parameters(&node.parameterEdges); err != nil {
+		return err
+	}
+
+	return sr.terminal(&node.wildcardEdges.term, graph.KeyWildcard{})
+}
+
+func (sr snapshotReader[V, P]) nodeParameter(node *nodeParameter[V, P]) error {
+	if err := sr.terminal(&node.valueEdges.term); err != nil {
+		return err
+	}
+
+	if err := sr.constants(&node.constantEdges); err != nil {
+		return err
+	}
+
+	return sr.terminal(&node.wildcardEdges.term, graph.KeyWildcard{})
+}
+
+func WriteSnapshot[V any, P comparable](w io.Writer, tree Tree[V, P], codec SnapshotCodec[V, P]) error {
+	sw := &snapshotWriter[V, P]{codec: codec.withDefaults()}
+
+	if err := sw.nodeConstant(&tree.root); err != nil {
+		return err
+	}
+
+	var (
+		payload = sw.buf.Bytes()
+		header  = make([]byte, snapshotHeaderLen)
+		trailer = make([]byte, snapshotTrailerLen)
+	)
+
+	copy(header, snapshotMagic)
+	binary.BigEndian.PutUint16(header[len(snapshotMagic):], snapshotVersion)
+	binary.BigEndian.PutUint32(trailer, crc32.ChecksumIEEE(payload))
+
+	for _, chunk := range [][]byte{header, payload, trailer} {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadSnapshot replaces the routes of into with those read from r, keeping its
+// Coexist and Policy. On error, into is left unchanged.
+func ReadSnapshot[V any, P comparable](r io.Reader, codec SnapshotCodec[V, P], into *Tree[V, P]) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if len(data) < snapshotHeaderLen+snapshotTrailerLen || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return fmt.Errorf("%w: bad header", ErrSnapshotFormat)
+	}
+
+	if version := binary.BigEndian.Uint16(data[len(snapshotMagic):]); version != snapshotVersion {
+		return fmt.Errorf("%w: got %d, expected %d", ErrSnapshotVersion, version, snapshotVersion)
+	}
+
+	var (
+		payload  = data[snapshotHeaderLen : len(data)-snapshotTrailerLen]
+		checksum = binary.BigEndian.Uint32(data[len(data)-snapshotTrailerLen:])
+	)
+
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return ErrSnapshotChecksum
+	}
+
+	var (
+		res = new(Tree[V, P])
+		sr  = snapshotReader[V, P]{
+			buf:   bytes.NewReader(payload),
+			codec: codec.withDefaults(),
+			path:  new(viewBuilder),
+			tree:  res,
+		}
+	)
+
+	if err := sr.nodeConstant(&res.root); err != nil {
+		return err
+	}
+
+	if sr.buf.Len() > 0 {
+		return fmt.Errorf("%w: trailing data", ErrSnapshotFormat)
+	}
+
+	into.root, into.sequence = res.root, res.sequence
+	return nil
+}
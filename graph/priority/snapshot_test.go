@@ -0,0 +1,80 @@
+package priority_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	graphtest "github.com/oligarch316/go-urlrouter/graph/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotTree(t *testing.T) (priority.Tree[string, string], []byte) {
+	var (
+		buf  bytes.Buffer
+		tree = fixtureTree(t, new(priority.Tree[string, string]),
+			graphtest.Path("valRoot"),
+			graphtest.Path("valA", keyA),
+			graphtest.Path("valAWild", keyA, keyWild),
+			graphtest.Path("valParam", keyParam1),
+			graphtest.Path("valParamParamA", keyParam1, keyParam2, keyA),
+		)
+	)
+
+	require.NoError(t, priority.WriteSnapshot[string, string](&buf, *tree, priority.SnapshotCodec[string, string]{}))
+	return *tree, buf.Bytes()
+}
+
+func TestGraphPrioritySnapshot(t *testing.T) {
+	original, data := snapshotTree(t)
+
+	loaded := new(priority.Tree[string, string])
+	require.NoError(t, priority.ReadSnapshot(bytes.NewReader(data), priority.SnapshotCodec[string, string]{}, loaded))
+
+	assert.Equal(t, original.View(), loaded.View())
+
+	for _, query := range [][]string{{"a", "x"}, {"x", "y", "a"}} {
+		var (
+			expected = search.First[string, string](original, query...)
+			actual   = search.First[string, string](loaded, query...)
+		)
+
+		if assert.NotNil(t, actual, graph.FormatQuery(query...)) {
+			assert.Equal(t, expected.Path, actual.Path, graph.FormatQuery(query...))
+		}
+	}
+}
+
+func TestGraphPrioritySnapshotError(t *testing.T) {
+	_, data := snapshotTree(t)
+
+	corrupt := func(idx int) []byte {
+		res := append([]byte(nil), data...)
+		res[idx] ^= 0xff
+		return res
+	}
+
+	subtests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{name: "empty", data: nil, expected: priority.ErrSnapshotFormat},
+		{name: "magic", data: corrupt(0), expected: priority.ErrSnapshotFormat},
+		{name: "version", data: corrupt(5), expected: priority.ErrSnapshotVersion},
+		{name: "payload", data: corrupt(len(data) / 2), expected: priority.ErrSnapshotChecksum},
+		{name: "checksum", data: corrupt(len(data) - 1), expected: priority.ErrSnapshotChecksum},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			err := priority.ReadSnapshot(bytes.NewReader(st.data), priority.SnapshotCodec[string, string]{}, new(priority.Tree[string, string]))
+			assert.ErrorIs(t, err, st.expected)
+		})
+	}
+}
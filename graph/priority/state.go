@@ -1,15 +1,81 @@
 package priority
 
-import "github.com/oligarch316/go-urlrouter/graph"
+import (
+	"context"
 
-type stateAdd[V any] struct {
-	parameterKeys []string
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+type stateAdd[V any, P comparable] struct {
+	coexist       func(existing, value V) bool
+	guard         graph.Guard[V, P]
+	parameterKeys []P
+	path          []graph.Key
+	sequence      int
 	value         V
 }
 
-type stateSearch[V any] struct {
+// NOTE: Checking the context is comparatively costly, so it happens only once
+// every searchCheckInterval steps, starting with the first.
+const searchCheckInterval = 64
+
+type searchControl struct {
+	ctx    context.Context
+	err    error
+	budget int
+	steps  int
+}
+
+// A budget below zero is unbounded
+func newSearchControl(ctx context.Context, budget int) *searchControl {
+	return &searchControl{ctx: ctx, budget: budget}
+}
+
+func (sc *searchControl) halt() bool {
+	if sc == nil {
+		return false
+	}
+
+	if sc.err != nil {
+		return true
+	}
+
+	if sc.steps%searchCheckInterval == 0 {
+		if sc.err = sc.ctx.Err(); sc.err != nil {
+			return true
+		}
+	}
+
+	if sc.budget >= 0 && sc.steps >= sc.budget {
+		sc.err = graph.ErrSearchBudget
+		return true
+	}
+
+	sc.steps++
+	return false
+}
+
+type stateSearch[V any, P comparable] struct {
+	buffer          *graph.SearchBuffer[V, P]
+	control         *searchControl
+	matches         *[]Match[V, P]
 	parameterValues []string
-	visitor         graph.Searcher[V]
+	ranks           *int
+	visitor         graph.Searcher[V, P]
+}
+
+func (ss stateSearch[V, P]) context() context.Context {
+	if ss.control == nil {
+		return context.Background()
+	}
+
+	return ss.control.ctx
+}
+
+func (ss stateSearch[V, P]) rank() int {
+	res := *ss.ranks
+	*ss.ranks++
+	return res
 }
 
 type stateWalk[V any] struct {
@@ -1,25 +1,110 @@
 package priority
 
-import "github.com/oligarch316/go-urlrouter/graph"
+import (
+	"context"
 
-type Tree[V any] struct{ root nodeConstant[V] }
+	"github.com/oligarch316/go-urlrouter/graph"
+)
 
-func (t *Tree[V]) Add(value V, path ...graph.Key) error {
-	return t.root.add(path, stateAdd[V]{value: value})
+// NOTE: A nil Policy searches lazily in default priority order, whereas any
+// other policy must first collect every match.
+type Tree[V any, P comparable] struct {
+	// Coexist, when set, allows several values to be added with the same path
+	// so long as it reports true for the new value against each existing one.
+	Coexist func(existing, value V) bool
+	Policy  Policy[V, P]
+
+	root     nodeConstant[V, P]
+	sequence int
+}
+
+func (t *Tree[V, P]) Add(value V, path ...graph.Key) error {
+	return t.AddGuarded(value, nil, path...)
+}
+
+func (t *Tree[V, P]) AddGuarded(value V, guard graph.Guard[V, P], path ...graph.Key) error {
+	return t.addSequence(value, guard, t.sequence, path)
+}
+
+// NOTE: Later adds are sequenced after the given sequence, as when loading
+func (t *Tree[V, P]) addSequence(value V, guard graph.Guard[V, P], sequence int, path []graph.Key) error {
+	state := stateAdd[V, P]{
+		coexist:  t.Coexist,
+		guard:    guard,
+		path:     append([]graph.Key(nil), path...),
+		sequence: sequence,
+		value:    value,
+	}
+
+	if sequence >= t.sequence {
+		t.sequence = sequence + 1
+	}
+
+	return t.root.add(path, state)
+}
+
+func (t Tree[V, P]) Search(searcher graph.Searcher[V, P], query ...string) {
+	if t.Policy != nil {
+		t.searchPolicy(nil, nil, searcher, query)
+		return
+	}
+
+	t.root.search(query, stateSearch[V, P]{ranks: new(int), visitor: searcher})
 }
 
-func (t Tree[V]) Search(searcher graph.Searcher[V], query ...string) {
-	t.root.search(query, stateSearch[V]{visitor: searcher})
+func (t Tree[V, P]) SearchBuffered(buffer *graph.SearchBuffer[V, P], searcher graph.Searcher[V, P], query ...string) {
+	// NOTE: Collected matches cannot share a buffer, so policies always allocate
+	if t.Policy != nil {
+		buffer.Count = t.searchPolicy(buffer, nil, searcher, query)
+		return
+	}
+
+	if cap(buffer.ParameterValues) < len(query) {
+		buffer.ParameterValues = make([]string, 0, len(query))
+	}
+
+	buffer.Count = 0
+
+	state := stateSearch[V, P]{
+		buffer:          buffer,
+		parameterValues: buffer.ParameterValues[:0],
+		ranks:           &buffer.Count,
+		visitor:         searcher,
+	}
+
+	t.root.search(query, state)
+}
+
+func (t Tree[V, P]) SearchContext(ctx context.Context, searcher graph.Searcher[V, P], query ...string) error {
+	return t.SearchBudget(ctx, -1, searcher, query...)
 }
 
-func (t Tree[V]) SearchFunc(searcher func(result *graph.SearchResult[V]) (done bool), query ...string) {
-	t.Search(graph.SearcherFunc[V](searcher), query...)
+// SearchBudget is SearchContext failing with graph.ErrSearchBudget after steps
+// nodes have been visited, or never if steps is negative.
+func (t Tree[V, P]) SearchBudget(ctx context.Context, steps int, searcher graph.Searcher[V, P], query ...string) error {
+	control := newSearchControl(ctx, steps)
+
+	if t.Policy != nil {
+		t.searchPolicy(nil, control, searcher, query)
+		return control.err
+	}
+
+	t.root.search(query, stateSearch[V, P]{control: control, ranks: new(int), visitor: searcher})
+	return control.err
+}
+
+func (t Tree[V, P]) SearchFunc(searcher func(result *graph.SearchResult[V, P]) (done bool), query ...string) {
+	t.Search(graph.SearcherFunc[V, P](searcher), query...)
 }
 
-func (t Tree[V]) Walk(walker graph.Walker[V]) {
+func (t Tree[V, P]) Walk(walker graph.Walker[V]) {
 	t.root.walk(stateWalk[V]{visitor: walker})
 }
 
-func (t Tree[V]) WalkFunc(walker func(value V) (done bool)) {
+func (t Tree[V, P]) WalkFunc(walker func(value V) (done bool)) {
 	t.Walk(graph.WalkerFunc[V](walker))
 }
+
+func (t Tree[V, P]) View() *ViewNode[V, P] {
+	return t.root.view(new(viewBuilder))
+}
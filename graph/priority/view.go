@@ -0,0 +1,270 @@
+package priority
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+type EdgeKind uint8
+
+const (
+	EdgeKindValue EdgeKind = iota
+	EdgeKindConstant
+	EdgeKindParameter
+	EdgeKindWildcard
+)
+
+func (ek EdgeKind) String() string {
+	switch ek {
+	case EdgeKindValue:
+		return "value"
+	case EdgeKindConstant:
+		return "const"
+	case EdgeKindParameter:
+		return "param"
+	case EdgeKindWildcard:
+		return "wild"
+	}
+
+	return "unknown"
+}
+
+// ViewNode is a read-only snapshot of a tree node. Edges are ordered value,
+// constants (by name), parameters (by arity), wildcard.
+type ViewNode[V any, P comparable] struct{ Edges []ViewEdge[V, P] }
+
+type ViewEdge[V any, P comparable] struct {
+	Kind     EdgeKind
+	Constant string
+
+	// NOTE: Parameter names are stored per terminal rather than per edge, so Names
+	// holds every distinct name list registered through a parameter edge.
+	Arity int
+	Names [][]P
+
+	Node *ViewNode[V, P]
+
+	// NOTE: Terminals are in registration order, and only hold more than one
+	// value when the tree allows values to coexist.
+	Terminals []*ViewTerminal[V, P]
+}
+
+func (ve ViewEdge[V, P]) String() string {
+	switch ve.Kind {
+	case EdgeKindConstant:
+		return edgeConstant(ve.Constant).String()
+	case EdgeKindParameter:
+		strs := make([]string, len(ve.Names))
+		for i, names := range ve.Names {
+			strs[i] = formatParameters(names)
+		}
+		return fmt.Sprintf("param(%s)", strings.Join(strs, " | "))
+	}
+
+	return ve.Kind.String()
+}
+
+func (ve ViewEdge[V, P]) FormatValues() string {
+	strs := make([]string, len(ve.Terminals))
+	for i, terminal := range ve.Terminals {
+		strs[i] = graph.FormatValue(terminal.Value)
+	}
+	return strings.Join(strs, ", ")
+}
+
+type ViewTerminal[V any, P comparable] struct {
+	Guarded  bool
+	Path     []graph.Key
+	Sequence int
+	Value    V
+}
+
+type viewBuilder struct{ path []graph.Key }
+
+func (vb *viewBuilder) push(keys ...graph.Key) { vb.path = append(vb.path, keys...) }
+func (vb *viewBuilder) pop(n int)              { vb.path = vb.path[:len(vb.path)-n] }
+
+func (vb *viewBuilder) parameters() int {
+	var res int
+	for _, key := range vb.path {
+		if key == nil {
+			res++
+		}
+	}
+	return res
+}
+
+// NOTE: Parameter edges are pushed as nil placeholders and named per terminal.
+func terminalPath[P comparable](vb *viewBuilder, parameterKeys []P, tail ...graph.Key) []graph.Key {
+	res := make([]graph.Key, 0, len(vb.path)+len(tail))
+
+	var i int
+	for _, key := range vb.path {
+		if key == nil {
+			key = graph.KeyParameter[P]{Name: parameterKeys[i]}
+			i++
+		}
+
+		res = append(res, key)
+	}
+
+	return append(res, tail...)
+}
+
+func viewTerminal[V any, P comparable](vb *viewBuilder, kind EdgeKind, term edgeSetTerminal[V, P]) (ViewEdge[V, P], bool) {
+	if len(term.nodes) == 0 {
+		return ViewEdge[V, P]{}, false
+	}
+
+	var tail []graph.Key
+	if kind == EdgeKindWildcard {
+		tail = append(tail, graph.KeyWildcard{})
+	}
+
+	terminals := make([]*ViewTerminal[V, P], len(term.nodes))
+	for i, node := range term.nodes {
+		terminals[i] = &ViewTerminal[V, P]{
+			Guarded:  node.guard != nil,
+			Path:     terminalPath(vb, node.parameterKeys, tail...),
+			Sequence: node.sequence,
+			Value:    node.value,
+		}
+	}
+
+	return ViewEdge[V, P]{Kind: kind, Terminals: terminals}, true
+}
+
+func viewConstants[V any, P comparable](vb *viewBuilder, esc edgeSetConstant[V, P]) []ViewEdge[V, P] {
+	names := make([]string, 0, len(esc))
+	for e := range esc {
+		names = append(names, string(e))
+	}
+	sort.Strings(names)
+
+	res := make([]ViewEdge[V, P], len(names))
+	for i, name := range names {
+		vb.push(graph.KeyConstant(name))
+		res[i] = ViewEdge[V, P]{
+			Kind:     EdgeKindConstant,
+			Constant: name,
+			Node:     esc[edgeConstant(name)].view(vb),
+		}
+		vb.pop(1)
+	}
+
+	return res
+}
+
+func viewParameters[V any, P comparable](vb *viewBuilder, esp edgeSetParameter[V, P]) []ViewEdge[V, P] {
+	res := make([]ViewEdge[V, P], len(esp.nList))
+
+	for i, n := range esp.nList {
+		offset := vb.parameters()
+
+		vb.push(make([]graph.Key, n)...)
+		node := esp.nMap[n].view(vb)
+		vb.pop(n)
+
+		res[i] = ViewEdge[V, P]{
+			Kind:  EdgeKindParameter,
+			Arity: n,
+			Names: viewNames(node, offset, n),
+			Node:  node,
+		}
+	}
+
+	return res
+}
+
+func viewNames[V any, P comparable](node *ViewNode[V, P], offset, n int) [][]P {
+	var res [][]P
+
+	node.visitTerminals(func(terminal *ViewTerminal[V, P]) {
+		var names []P
+		for _, key := range terminal.Path {
+			if param, ok := key.(graph.KeyParameter[P]); ok {
+				names = append(names, param.Name)
+			}
+		}
+
+		names = names[offset : offset+n]
+
+		for _, item := range res {
+			if equalNames(item, names) {
+				return
+			}
+		}
+
+		res = append(res, names)
+	})
+
+	return res
+}
+
+func equalNames[P comparable](a, b []P) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (vn *ViewNode[V, P]) visitTerminals(visit func(*ViewTerminal[V, P])) {
+	for _, e := range vn.Edges {
+		for _, terminal := range e.Terminals {
+			visit(terminal)
+		}
+
+		if e.Node != nil {
+			e.Node.visitTerminals(visit)
+		}
+	}
+}
+
+func (vn *ViewNode[V, P]) Terminals() []*ViewTerminal[V, P] {
+	var res []*ViewTerminal[V, P]
+	vn.visitTerminals(func(terminal *ViewTerminal[V, P]) { res = append(res, terminal) })
+	return res
+}
+
+func (nc nodeConstant[V, P]) view(vb *viewBuilder) *ViewNode[V, P] {
+	res := new(ViewNode[V, P])
+
+	if e, ok := viewTerminal(vb, EdgeKindValue, nc.valueEdges.term); ok {
+		res.Edges = append(res.Edges, e)
+	}
+
+	res.Edges = append(res.Edges, viewConstants(vb, nc.constantEdges)...)
+	res.Edges = append(res.Edges, viewParameters(vb, nc.parameterEdges)...)
+
+	if e, ok := viewTerminal(vb, EdgeKindWildcard, nc.wildcardEdges.term); ok {
+		res.Edges = append(res.Edges, e)
+	}
+
+	return res
+}
+
+func (np nodeParameter[V, P]) view(vb *viewBuilder) *ViewNode[V, P] {
+	res := new(ViewNode[V, P])
+
+	if e, ok := viewTerminal(vb, EdgeKindValue, np.valueEdges.term); ok {
+		res.Edges = append(res.Edges, e)
+	}
+
+	res.Edges = append(res.Edges, viewConstants(vb, np.constantEdges)...)
+
+	if e, ok := viewTerminal(vb, EdgeKindWildcard, np.wildcardEdges.term); ok {
+		res.Edges = append(res.Edges, e)
+	}
+
+	return res
+}
@@ -0,0 +1,43 @@
+package search
+
+import (
+	"sync"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+type BufferPool[V any, P comparable] struct{ pool sync.Pool }
+
+func (bp *BufferPool[V, P]) Get() *graph.SearchBuffer[V, P] {
+	if buffer, ok := bp.pool.Get().(*graph.SearchBuffer[V, P]); ok {
+		return buffer
+	}
+
+	return new(graph.SearchBuffer[V, P])
+}
+
+func (bp *BufferPool[V, P]) Put(buffer *graph.SearchBuffer[V, P]) {
+	var zero V
+	buffer.Result.Value = zero
+	buffer.Result.Path = nil
+	buffer.Result.Tail = nil
+	buffer.Result.ParameterNames = nil
+	buffer.Result.ParameterValues = nil
+
+	bp.pool.Put(buffer)
+}
+
+// NOTE: Zero sized so that conversion to graph.Searcher does not allocate.
+type visitorStop[V any, P comparable] struct{}
+
+func (visitorStop[V, P]) VisitSearch(*graph.SearchResult[V, P]) bool { return true }
+
+func FirstBuffered[V any, P comparable](tree graph.BufferedTree[V, P], buffer *graph.SearchBuffer[V, P], query ...string) *graph.SearchResult[V, P] {
+	tree.SearchBuffered(buffer, visitorStop[V, P]{}, query...)
+
+	if buffer.Count > 0 {
+		return &buffer.Result
+	}
+
+	return nil
+}
@@ -2,30 +2,30 @@ package search
 
 import "github.com/oligarch316/go-urlrouter/graph"
 
-type Searchable[V any] interface {
-	Search(graph.Searcher[V], ...string)
+type Searchable[V any, P comparable] interface {
+	Search(graph.Searcher[V, P], ...string)
 }
 
-func All[V any](tree Searchable[V], query ...string) []*graph.SearchResult[V] {
-	visitor := new(VisitorAll[V])
+func All[V any, P comparable](tree Searchable[V, P], query ...string) []*graph.SearchResult[V, P] {
+	visitor := new(VisitorAll[V, P])
 	tree.Search(visitor, query...)
 	return visitor.Results
 }
 
-func AllPredicate[V any](tree Searchable[V], predicate func(*graph.SearchResult[V]) bool, query ...string) []*graph.SearchResult[V] {
-	visitor := &VisitorAllPredicate[V]{Predicate: predicate}
+func AllPredicate[V any, P comparable](tree Searchable[V, P], predicate func(*graph.SearchResult[V, P]) bool, query ...string) []*graph.SearchResult[V, P] {
+	visitor := &VisitorAllPredicate[V, P]{Predicate: predicate}
 	tree.Search(visitor, query...)
 	return visitor.Results
 }
 
-func First[V any](tree Searchable[V], query ...string) *graph.SearchResult[V] {
-	visitor := new(VisitorFirst[V])
+func First[V any, P comparable](tree Searchable[V, P], query ...string) *graph.SearchResult[V, P] {
+	visitor := new(VisitorFirst[V, P])
 	tree.Search(visitor, query...)
 	return visitor.Result
 }
 
-func FirstPredicate[V any](tree Searchable[V], predicate func(*graph.SearchResult[V]) bool, query ...string) *graph.SearchResult[V] {
-	visitor := &VisitorFirstPredicate[V]{Predicate: predicate}
+func FirstPredicate[V any, P comparable](tree Searchable[V, P], predicate func(*graph.SearchResult[V, P]) bool, query ...string) *graph.SearchResult[V, P] {
+	visitor := &VisitorFirstPredicate[V, P]{Predicate: predicate}
 	tree.Search(visitor, query...)
 	return visitor.Result
 }
@@ -2,19 +2,19 @@ package search
 
 import "github.com/oligarch316/go-urlrouter/graph"
 
-type VisitorAll[V any] struct{ Results []*graph.SearchResult[V] }
+type VisitorAll[V any, P comparable] struct{ Results []*graph.SearchResult[V, P] }
 
-func (va *VisitorAll[V]) VisitSearch(result *graph.SearchResult[V]) bool {
+func (va *VisitorAll[V, P]) VisitSearch(result *graph.SearchResult[V, P]) bool {
 	va.Results = append(va.Results, result)
 	return false
 }
 
-type VisitorAllPredicate[V any] struct {
-	Predicate func(*graph.SearchResult[V]) bool
-	Results   []*graph.SearchResult[V]
+type VisitorAllPredicate[V any, P comparable] struct {
+	Predicate func(*graph.SearchResult[V, P]) bool
+	Results   []*graph.SearchResult[V, P]
 }
 
-func (vap *VisitorAllPredicate[V]) VisitSearch(result *graph.SearchResult[V]) bool {
+func (vap *VisitorAllPredicate[V, P]) VisitSearch(result *graph.SearchResult[V, P]) bool {
 	if vap.Predicate(result) {
 		vap.Results = append(vap.Results, result)
 	}
@@ -22,19 +22,19 @@ func (vap *VisitorAllPredicate[V]) VisitSearch(result *graph.SearchResult[V]) bo
 	return false
 }
 
-type VisitorFirst[V any] struct{ Result *graph.SearchResult[V] }
+type VisitorFirst[V any, P comparable] struct{ Result *graph.SearchResult[V, P] }
 
-func (vf *VisitorFirst[V]) VisitSearch(result *graph.SearchResult[V]) bool {
+func (vf *VisitorFirst[V, P]) VisitSearch(result *graph.SearchResult[V, P]) bool {
 	vf.Result = result
 	return true
 }
 
-type VisitorFirstPredicate[V any] struct {
-	Predicate func(*graph.SearchResult[V]) bool
-	Result    *graph.SearchResult[V]
+type VisitorFirstPredicate[V any, P comparable] struct {
+	Predicate func(*graph.SearchResult[V, P]) bool
+	Result    *graph.SearchResult[V, P]
 }
 
-func (vfp *VisitorFirstPredicate[V]) VisitSearch(result *graph.SearchResult[V]) bool {
+func (vfp *VisitorFirstPredicate[V, P]) VisitSearch(result *graph.SearchResult[V, P]) bool {
 	if vfp.Predicate(result) {
 		vfp.Result = result
 		return true
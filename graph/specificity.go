@@ -0,0 +1,42 @@
+package graph
+
+// Specificity summarizes how a search result matched its query. Results with
+// more constants are most specific, followed by those without a wildcard, then
+// those with more parameters and finally those with a shorter tail.
+type Specificity struct {
+	Constants  int
+	Parameters int
+	Wildcard   bool
+	Tail       int
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+// Compare returns a positive number if s is more specific than other, a
+// negative number if it is less specific and zero if they are equivalent.
+func (s Specificity) Compare(other Specificity) int {
+	if res := compareInts(s.Constants, other.Constants); res != 0 {
+		return res
+	}
+
+	if s.Wildcard != other.Wildcard {
+		if s.Wildcard {
+			return -1
+		}
+		return 1
+	}
+
+	if res := compareInts(s.Parameters, other.Parameters); res != 0 {
+		return res
+	}
+
+	return compareInts(other.Tail, s.Tail)
+}
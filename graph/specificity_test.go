@@ -0,0 +1,34 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphSpecificityCompare(t *testing.T) {
+	// Ordered from most to least specific
+	ordered := []graph.Specificity{
+		{Constants: 2},
+		{Constants: 1, Parameters: 1},
+		{Constants: 1, Wildcard: true, Tail: 1},
+		{Parameters: 2},
+		{Parameters: 1, Wildcard: true, Tail: 1},
+		{Wildcard: true, Tail: 2},
+	}
+
+	for i, a := range ordered {
+		for j, b := range ordered {
+			var expected int
+			switch {
+			case i < j:
+				expected = 1
+			case i > j:
+				expected = -1
+			}
+
+			assert.Equal(t, expected, a.Compare(b), "compare %+v to %+v", a, b)
+		}
+	}
+}
@@ -13,9 +13,9 @@ func TestGraphAddError(t *testing.T) {
 		b = graph.KeyConstant("b")
 		c = graph.KeyConstant("c")
 
-		param1 = graph.KeyParameter("param1")
-		param2 = graph.KeyParameter("param2")
-		param3 = graph.KeyParameter("param3")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		param3 = graph.KeyParameter[string]{Name: "param3"}
 
 		wild = graph.KeyWildcard{}
 	)
@@ -39,6 +39,26 @@ func TestGraphAddError(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid key", func(t *testing.T) {
+		indexParam := graph.KeyParameter[int]{Name: 0}
+
+		subtests := []PathItem{
+			Path("someVal", indexParam),
+			Path("someVal", a, indexParam),
+			Path("someVal", param1, indexParam),
+		}
+
+		for _, path := range subtests {
+			var (
+				tree Tree
+				info = Info(path, &tree)
+			)
+
+			err := tree.Add(path.Value, path.Keys...)
+			assert.ErrorIs(t, err, graph.ErrInvalidKey, info)
+		}
+	})
+
 	t.Run("invalid continuation", func(t *testing.T) {
 		subtests := []struct {
 			path                 PathItem
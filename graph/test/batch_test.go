@@ -0,0 +1,127 @@
+package graphtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/graph/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphBatchSuccess(t *testing.T) {
+	var (
+		a    = graph.KeyConstant("a")
+		b    = graph.KeyConstant("b")
+		tree Tree
+	)
+
+	require.NoError(t, tree.Add("valA", a))
+
+	err := tree.Batch(func(tx graph.Adder[string]) error {
+		if err := tx.Add("valB", b); err != nil {
+			return err
+		}
+
+		return tx.Add("valAB", a, b)
+	})
+	require.NoError(t, err, Info(&tree))
+
+	for _, query := range []QueryItem{Query("a"), Query("b"), Query("a", "b")} {
+		assert.NotNil(t, search.First[string, string](tree, query...), Info(query, &tree))
+	}
+}
+
+func TestGraphBatchRollback(t *testing.T) {
+	var (
+		a    = graph.KeyConstant("a")
+		b    = graph.KeyConstant("b")
+		wild = graph.KeyWildcard{}
+		tree Tree
+	)
+
+	require.NoError(t, tree.Add("valA", a))
+
+	err := tree.Batch(func(tx graph.Adder[string]) error {
+		tx.Add("valB", b)
+		tx.Add("valADuplicate", a)
+		tx.Add("valWildB", wild, b)
+		return nil
+	})
+
+	var batchErr graph.BatchError
+	require.ErrorAs(t, err, &batchErr, Info(&tree))
+
+	if assert.Len(t, batchErr.Errors, 2, Info(&tree)) {
+		var addErr graph.BatchAddError
+
+		if assert.ErrorAs(t, batchErr.Errors[0], &addErr, Info(&tree)) {
+			assert.Equal(t, 1, addErr.Index, Info(&tree))
+			assert.Equal(t, graph.DuplicateValueError[string]{ExistingValue: "valA"}, addErr.Err, Info(&tree))
+		}
+
+		if assert.ErrorAs(t, batchErr.Errors[1], &addErr, Info(&tree)) {
+			assert.Equal(t, 2, addErr.Index, Info(&tree))
+			assert.ErrorAs(t, addErr.Err, new(graph.InvalidContinuationError), Info(&tree))
+		}
+	}
+
+	assert.Nil(t, search.First[string, string](tree, "b"), Info(Query("b"), &tree).Note("check rollback"))
+}
+
+func TestGraphBatchAbort(t *testing.T) {
+	var (
+		errAbort = errors.New("abort")
+		tree     Tree
+	)
+
+	err := tree.Batch(func(tx graph.Adder[string]) error {
+		if err := tx.Add("valA", graph.KeyConstant("a")); err != nil {
+			return err
+		}
+
+		return errAbort
+	})
+
+	assert.ErrorIs(t, err, errAbort)
+	assert.Nil(t, search.First[string, string](tree, "a"), Info(Query("a"), &tree).Note("check rollback"))
+}
+
+func TestGraphBatchReturnedError(t *testing.T) {
+	var (
+		a    = graph.KeyConstant("a")
+		tree Tree
+	)
+
+	require.NoError(t, tree.Add("valA", a))
+
+	err := tree.Batch(func(tx graph.Adder[string]) error {
+		return tx.Add("valADuplicate", a)
+	})
+
+	var batchErr graph.BatchError
+	require.ErrorAs(t, err, &batchErr, Info(&tree))
+	assert.Len(t, batchErr.Errors, 1, Info(&tree).Note("check error deduplication"))
+}
+
+func TestGraphBatchEqualErrors(t *testing.T) {
+	var (
+		a    = graph.KeyConstant("a")
+		tree Tree
+	)
+
+	require.NoError(t, tree.Add("valA", a))
+
+	err := tree.Batch(func(tx graph.Adder[string]) error {
+		tx.Add("valADuplicate1", a)
+		tx.Add("valADuplicate2", a)
+
+		// Equal in value to both recorded errors, yet not returned by Add
+		return graph.DuplicateValueError[string]{ExistingValue: "valA"}
+	})
+
+	var batchErr graph.BatchError
+	require.ErrorAs(t, err, &batchErr, Info(&tree))
+	assert.Len(t, batchErr.Errors, 3, Info(&tree).Note("check distinct errors"))
+}
@@ -3,9 +3,12 @@ package graphtest
 import (
 	"fmt"
 	"strings"
+	"testing"
 
 	"github.com/oligarch316/go-urlrouter/graph"
 	"github.com/oligarch316/go-urlrouter/graph/memoized"
+	"github.com/oligarch316/go-urlrouter/graph/priority"
+	"github.com/stretchr/testify/require"
 )
 
 type InfoMessage struct {
@@ -33,12 +36,18 @@ func (il InfoList) String() string {
 }
 
 type PathItem struct {
+	Guard graph.Guard[string, string]
 	Keys  []graph.Key
 	Value string
 }
 
 func (pi PathItem) String() string {
-	return fmt.Sprintf("--------\nPath:\n> %s", graph.FormatPath(pi.Value, pi.Keys...))
+	guardStr := ""
+	if pi.Guard != nil {
+		guardStr = " (guarded)"
+	}
+
+	return fmt.Sprintf("--------\nPath:\n> %s%s", graph.FormatPath(pi.Value, pi.Keys...), guardStr)
 }
 
 type QueryItem []string
@@ -52,7 +61,7 @@ func (qi QueryItem) String() string {
 	return fmt.Sprintf("--------\nQuery:\n> %s", dataStr)
 }
 
-type Tree struct{ memoized.Tree[string] }
+type Tree struct{ memoized.Tree[string, string] }
 
 func (t *Tree) String() string {
 	var paths []string
@@ -69,6 +78,50 @@ func (t *Tree) String() string {
 	return fmt.Sprintf("--------\nTree:\n%s", dataStr)
 }
 
+// PriorityTree is a priority.Tree configured beyond the default, as with a
+// Policy or Coexist, printed as Tree is
+type PriorityTree struct{ *priority.Tree[string, string] }
+
+func (pt PriorityTree) String() string {
+	var paths []string
+	for _, terminal := range pt.View().Terminals() {
+		paths = append(paths, "> "+graph.FormatPath(terminal.Value, terminal.Path...))
+	}
+
+	dataStr := "> <empty>"
+	if len(paths) > 0 {
+		dataStr = strings.Join(paths, "\n")
+	}
+
+	return fmt.Sprintf("--------\nTree:\n%s", dataStr)
+}
+
+type pathAdder interface {
+	graph.Adder[string]
+	fmt.Stringer
+}
+
+// AddPaths adds each path to tree in order, failing t with the path and tree
+// so far on the first error
+func AddPaths(t testing.TB, tree pathAdder, paths ...PathItem) {
+	t.Helper()
+
+	for _, path := range paths {
+		if path.Guard == nil {
+			require.NoError(t, tree.Add(path.Value, path.Keys...), Info(path, tree))
+			continue
+		}
+
+		adder, ok := tree.(graph.GuardedAdder[string, string])
+		require.True(t, ok, Info(path, tree).Note("check guarded adder"))
+		require.NoError(t, adder.AddGuarded(path.Value, path.Guard, path.Keys...), Info(path, tree))
+	}
+}
+
 func Info(items ...fmt.Stringer) InfoList           { return InfoList(items) }
 func Query(items ...string) QueryItem               { return QueryItem(items) }
 func Path(value string, keys ...graph.Key) PathItem { return PathItem{Keys: keys, Value: value} }
+
+func GuardedPath(value string, guard graph.Guard[string, string], keys ...graph.Key) PathItem {
+	return PathItem{Guard: guard, Keys: keys, Value: value}
+}
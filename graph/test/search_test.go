@@ -1,13 +1,14 @@
 package graphtest
 
 import (
+	"context"
 	"testing"
 
 	"github.com/oligarch316/go-urlrouter/graph"
 	"github.com/stretchr/testify/assert"
 )
 
-type searchResult graph.SearchResult[string]
+type searchResult graph.SearchResult[string, string]
 
 type searchResultList []searchResult
 
@@ -21,7 +22,7 @@ func (srl searchResultList) values() []string {
 
 type searchVisitor struct{ actual searchResultList }
 
-func (sv *searchVisitor) VisitSearch(result *graph.SearchResult[string]) bool {
+func (sv *searchVisitor) VisitSearch(result *graph.SearchResult[string, string]) bool {
 	sv.actual = append(sv.actual, searchResult(*result))
 	return false
 }
@@ -32,14 +33,14 @@ func TestGraphSearch(t *testing.T) {
 		b = graph.KeyConstant("b")
 		c = graph.KeyConstant("c")
 
-		param1 = graph.KeyParameter("param1")
-		param2 = graph.KeyParameter("param2")
-		param3 = graph.KeyParameter("param3")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		param3 = graph.KeyParameter[string]{Name: "param3"}
 
 		wild = graph.KeyWildcard{}
 	)
 
-	type Result graph.SearchResult[string]
+	type Result graph.SearchResult[string, string]
 
 	type searchTest struct {
 		query    QueryItem
@@ -295,6 +296,39 @@ func TestGraphSearch(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Parameters never capture an empty segment
+
+			paths: []PathItem{
+				Path("valEmpty", a, graph.KeyConstant("")),
+				Path("valParam", a, param1),
+				Path("valParamParam", a, param1, param2),
+				Path("valWild", a, wild),
+			},
+			searches: []searchTest{
+				{
+					query: Query("a", ""),
+					expected: searchResultList{
+						{
+							Value: "valEmpty",
+						},
+						{
+							Value: "valWild",
+							Tail:  []string{""},
+						},
+					},
+				},
+				{
+					query: Query("a", "seg1", ""),
+					expected: searchResultList{
+						{
+							Value: "valWild",
+							Tail:  []string{"seg1", ""},
+						},
+					},
+				},
+			},
+		},
 	}
 
 L:
@@ -334,3 +368,213 @@ L:
 		}
 	}
 }
+
+func TestGraphSearchContext(t *testing.T) {
+	var (
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		param3 = graph.KeyParameter[string]{Name: "param3"}
+		wild   = graph.KeyWildcard{}
+
+		tree  Tree
+		query = Query("seg1", "seg2", "seg3", "seg4")
+	)
+
+	paths := []PathItem{
+		Path("valParam", param1, wild),
+		Path("valParamParam", param1, param2, wild),
+		Path("valParamParamParam", param1, param2, param3, wild),
+	}
+
+	for _, path := range paths {
+		if err := tree.Add(path.Value, path.Keys...); !assert.NoError(t, err, Info(path, &tree)) {
+			return
+		}
+	}
+
+	t.Run("unbounded", func(t *testing.T) {
+		visitor := new(searchVisitor)
+
+		err := tree.SearchContext(context.Background(), visitor, query...)
+		assert.NoError(t, err, Info(query, &tree))
+		assert.Len(t, visitor.actual, 3, Info(query, &tree))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		var (
+			visitor     = new(searchVisitor)
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+
+		cancel()
+
+		err := tree.SearchContext(ctx, visitor, query...)
+		assert.ErrorIs(t, err, context.Canceled, Info(query, &tree))
+		assert.Empty(t, visitor.actual, Info(query, &tree))
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		visitor := new(searchVisitor)
+
+		err := tree.SearchBudget(context.Background(), 3, visitor, query...)
+		assert.ErrorIs(t, err, graph.ErrSearchBudget, Info(query, &tree))
+		assert.Less(t, len(visitor.actual), 3, Info(query, &tree))
+	})
+
+	t.Run("budget sufficient", func(t *testing.T) {
+		visitor := new(searchVisitor)
+
+		err := tree.SearchBudget(context.Background(), 100, visitor, query...)
+		assert.NoError(t, err, Info(query, &tree))
+		assert.Len(t, visitor.actual, 3, Info(query, &tree))
+	})
+}
+
+func TestGraphSearchPositional(t *testing.T) {
+	var (
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		wild   = graph.KeyWildcard{}
+
+		tree  Tree
+		query = Query("seg1", "seg2", "seg3")
+	)
+
+	paths := []PathItem{
+		Path("valParam", param1, wild),
+		Path("valParamParam", param1, param1, wild),
+		Path("valParamParamParam", param1, param2, param1),
+	}
+
+	for _, path := range paths {
+		if err := tree.Add(path.Value, path.Keys...); !assert.NoError(t, err, Info(path, &tree)) {
+			return
+		}
+	}
+
+	visitor := new(searchVisitor)
+	tree.Search(visitor, query...)
+
+	expected := []struct {
+		value  string
+		names  []string
+		values []string
+	}{
+		{"valParamParamParam", []string{"param1", "param2", "param1"}, []string{"seg1", "seg2", "seg3"}},
+		{"valParamParam", []string{"param1", "param1"}, []string{"seg1", "seg2"}},
+		{"valParam", []string{"param1"}, []string{"seg1"}},
+	}
+
+	if !assert.Len(t, visitor.actual, len(expected), Info(query, &tree)) {
+		return
+	}
+
+	for i, item := range expected {
+		var (
+			actual = graph.SearchResult[string, string](visitor.actual[i])
+			info   = Info(query, &tree).Notef("index %d (%s)", i, item.value)
+		)
+
+		assert.Equal(t, item.value, actual.Value, info.Note("check value"))
+		assert.Equal(t, item.names, actual.ParameterNames, info.Note("check names"))
+		assert.Equal(t, item.values, actual.ParameterValues, info.Note("check values"))
+		assert.Equal(t, len(item.values), actual.NumParams(), info.Note("check count"))
+
+		for j := range item.values {
+			assert.Equal(t, item.names[j], actual.ParamName(j), info.Notef("check name %d", j))
+			assert.Equal(t, item.values[j], actual.Param(j), info.Notef("check param %d", j))
+		}
+
+		assert.Empty(t, actual.Param(len(item.values)), info.Note("check out of range"))
+	}
+}
+
+func TestGraphSearchPath(t *testing.T) {
+	var (
+		a      = graph.KeyConstant("a")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		param2 = graph.KeyParameter[string]{Name: "param2"}
+		wild   = graph.KeyWildcard{}
+	)
+
+	subtests := []struct {
+		path  PathItem
+		query QueryItem
+	}{
+		{path: Path("valRoot"), query: Query()},
+		{path: Path("valA", a), query: Query("a")},
+		{path: Path("valParamA", param1, a), query: Query("x", "a")},
+		{path: Path("valParamParamWild", param1, param2, wild), query: Query("x", "y", "z")},
+	}
+
+	var tree Tree
+
+	for _, subtest := range subtests {
+		if err := tree.Add(subtest.path.Value, subtest.path.Keys...); !assert.NoError(t, err, Info(subtest.path, &tree)) {
+			return
+		}
+	}
+
+	for _, subtest := range subtests {
+		var (
+			visitor = new(searchVisitor)
+			info    = Info(subtest.query, &tree)
+		)
+
+		tree.Search(visitor, subtest.query...)
+
+		if assert.NotEmpty(t, visitor.actual, info) {
+			assert.Equal(t, subtest.path.Value, visitor.actual[0].Value, info.Note("check value"))
+			assert.Equal(t, subtest.path.Keys, visitor.actual[0].Path, info.Note("check path"))
+		}
+	}
+}
+
+func TestGraphSearchRank(t *testing.T) {
+	var (
+		a      = graph.KeyConstant("a")
+		b      = graph.KeyConstant("b")
+		param1 = graph.KeyParameter[string]{Name: "param1"}
+		wild   = graph.KeyWildcard{}
+
+		tree  Tree
+		query = Query("a", "b")
+	)
+
+	paths := []PathItem{
+		Path("valAB", a, b),
+		Path("valAParam", a, param1),
+		Path("valAWild", a, wild),
+		Path("valParamB", param1, b),
+		Path("valWild", wild),
+	}
+
+	for _, path := range paths {
+		if err := tree.Add(path.Value, path.Keys...); !assert.NoError(t, err, Info(path, &tree)) {
+			return
+		}
+	}
+
+	expected := []graph.Specificity{
+		{Constants: 2},
+		{Constants: 1, Parameters: 1},
+		{Constants: 1, Wildcard: true, Tail: 1},
+		{Constants: 1, Parameters: 1},
+		{Wildcard: true, Tail: 2},
+	}
+
+	visitor := new(searchVisitor)
+	tree.Search(visitor, query...)
+
+	if !assert.Len(t, visitor.actual, len(expected), Info(query, &tree)) {
+		return
+	}
+
+	for i, actual := range visitor.actual {
+		result := graph.SearchResult[string, string](actual)
+		info := Info(query, &tree).Notef("index %d (%s)", i, actual.Value)
+
+		assert.Equal(t, i, actual.Rank, info.Note("check rank"))
+		assert.Equal(t, expected[i], result.Specificity(), info.Note("check specificity"))
+	}
+}
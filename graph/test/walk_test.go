@@ -17,7 +17,7 @@ func (wv *walkVisitor) VisitWalk(value string) bool {
 func TestGraphWalk(t *testing.T) {
 	var (
 		a     = graph.KeyConstant("a")
-		param = graph.KeyParameter("param")
+		param = graph.KeyParameter[string]{Name: "param"}
 		wild  = graph.KeyWildcard{}
 	)
 
@@ -0,0 +1,40 @@
+package mux
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/component"
+)
+
+// CleanPath routes requests as though "." and ".." segments were resolved and
+// repeated slashes collapsed.
+func CleanPath(m *Mux) { component.URLCleanPath(m.Router) }
+
+// NOTE: As with http.ServeMux, a trailing slash survives cleaning.
+func cleanPath(p string) string {
+	res := path.Clean(p)
+
+	if strings.HasSuffix(p, "/") && res != "/" {
+		res += "/"
+	}
+
+	return res
+}
+
+func (m *Mux) redirectCleanPath(w http.ResponseWriter, r *http.Request) bool {
+	if m.CleanPathRedirect == 0 || r.Method == http.MethodConnect {
+		return false
+	}
+
+	p := m.requestPath(r)
+
+	cleaned := cleanPath(p)
+	if cleaned == p {
+		return false
+	}
+
+	m.redirect(w, r, cleaned, m.CleanPathRedirect)
+	return true
+}
@@ -0,0 +1,48 @@
+package mux_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuxCleanPath(t *testing.T) {
+	t.Run("route", func(t *testing.T) {
+		m := mux.New(mux.CleanPath)
+		require.NoError(t, m.HandlePath("/a/:id", echoHandler("a")))
+
+		assert.Equal(t, "a map[id:1]", serve(m, "example.com", "/a//1").Body.String())
+		assert.Equal(t, "a map[id:1]", serve(m, "example.com", "/x/../a/./1").Body.String())
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		m := mux.New()
+		m.CleanPathRedirect = http.StatusMovedPermanently
+		require.NoError(t, m.HandlePath("/a/:id", echoHandler("a")))
+
+		subtests := []struct {
+			name, target, expectedLocation string
+			expectedCode                   int
+		}{
+			{name: "clean", target: "/a/1", expectedCode: http.StatusOK},
+			{name: "duplicate slash", target: "/a//1?q=1", expectedLocation: "/a/1?q=1", expectedCode: http.StatusMovedPermanently},
+			{name: "dot segments", target: "/x/../a/./1", expectedLocation: "/a/1", expectedCode: http.StatusMovedPermanently},
+			{name: "trailing slash kept", target: "/a/./1/", expectedLocation: "/a/1/", expectedCode: http.StatusMovedPermanently},
+			{name: "leading double slash", target: "//evil.example/a", expectedLocation: "/evil.example/a", expectedCode: http.StatusMovedPermanently},
+		}
+
+		for _, subtest := range subtests {
+			st := subtest
+
+			t.Run(st.name, func(t *testing.T) {
+				rec := serve(m, "example.com", st.target)
+
+				assert.Equal(t, st.expectedCode, rec.Code)
+				assert.Equal(t, st.expectedLocation, rec.Header().Get("Location"))
+			})
+		}
+	})
+}
@@ -0,0 +1,8 @@
+package mux
+
+import "github.com/oligarch316/go-urlrouter/component"
+
+// EscapedPath routes requests by their escaped path, such that an encoded "%2F"
+// is matched and captured as part of a segment rather than split upon.
+// Patterns are then given in escaped form.
+func EscapedPath(m *Mux) { component.URLEscapedPath(m.Router) }
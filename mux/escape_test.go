@@ -0,0 +1,73 @@
+package mux_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuxEscapedPath(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		m := mux.New()
+		require.NoError(t, m.HandlePath("/files/:id", echoHandler("file")))
+
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/files/a%2Fb").Code)
+	})
+
+	t.Run("escaped", func(t *testing.T) {
+		m := mux.New(mux.EscapedPath)
+		require.NoError(t, m.HandlePath("/files/:id", echoHandler("file")))
+		require.NoError(t, m.HandlePath("/a%20b", echoHandler("space")))
+
+		assert.Equal(t, "file map[id:a/b]", serve(m, "example.com", "/files/a%2Fb").Body.String())
+		assert.Equal(t, "file map[id:a b]", serve(m, "example.com", "/files/a%20b").Body.String())
+		assert.Equal(t, "space map[]", serve(m, "example.com", "/a%20b").Body.String())
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/files/a/b").Code)
+	})
+
+	t.Run("path value", func(t *testing.T) {
+		m := mux.New(mux.EscapedPath)
+		require.NoError(t, m.HandlePathFunc("/files/:id", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.PathValue("id")))
+		}))
+
+		assert.Equal(t, "a/b", serve(m, "example.com", "/files/a%2Fb").Body.String())
+	})
+
+	t.Run("escaped dots", func(t *testing.T) {
+		m := mux.New(mux.EscapedPath, mux.CleanPath)
+		require.NoError(t, m.HandlePath("/secret", echoHandler("secret")))
+		require.NoError(t, m.HandlePath("/public/:f", echoHandler("public")))
+
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/public/%2E%2E/secret").Code)
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/public/%2e%2e/secret").Code)
+		assert.Equal(t, "public map[f:.]", serve(m, "example.com", "/public/%2e").Body.String())
+	})
+
+	t.Run("trailing slash after", func(t *testing.T) {
+		m := mux.New(mux.EscapedPath, mux.CleanPath, mux.DistinctTrailingSlash)
+		require.NoError(t, m.HandlePath("/files/:id", echoHandler("file")))
+
+		assert.Equal(t, "file map[id:a/b]", serve(m, "example.com", "/files/a%2Fb").Body.String())
+		assert.Equal(t, "file map[id:a]", serve(m, "example.com", "/x/../files/a").Body.String())
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/files/a/").Code)
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		m := mux.New(mux.DistinctTrailingSlash, mux.EscapedPath)
+		m.TrailingSlashRedirect = http.StatusPermanentRedirect
+		m.CleanPathRedirect = http.StatusMovedPermanently
+		require.NoError(t, m.HandlePath("/files/:id/", echoHandler("file")))
+
+		rec := serve(m, "example.com", "/files/a%2Fb")
+		assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+		assert.Equal(t, "/files/a%2Fb/", rec.Header().Get("Location"))
+
+		rec = serve(m, "example.com", "/x/../files/a%2Fb/")
+		assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+		assert.Equal(t, "/files/a%2Fb/", rec.Header().Get("Location"))
+	})
+}
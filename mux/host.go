@@ -0,0 +1,7 @@
+package mux
+
+import "github.com/oligarch316/go-urlrouter/component"
+
+// StrictHost routes requests by host regardless of case or a trailing root dot,
+// matching IP literals as a whole and rejecting invalid hosts as bad requests.
+func StrictHost(m *Mux) { component.URLStrictHost(m.Router) }
@@ -0,0 +1,93 @@
+package mux
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+// MethodHandler restricts Handler to requests using Method. Patterns of the
+// form "METHOD /path" register a MethodHandler.
+type MethodHandler struct {
+	Method  string
+	Handler http.Handler
+}
+
+func (mh MethodHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) { mh.Handler.ServeHTTP(w, r) }
+
+// NOTE: Handlers restricted to distinct methods may share a pattern, whereas an
+// unrestricted handler conflicts with every other.
+func coexistMethods(existing, value http.Handler) bool {
+	existingMethod, ok := existing.(MethodHandler)
+	if !ok {
+		return false
+	}
+
+	valueMethod, ok := value.(MethodHandler)
+	return ok && existingMethod.Method != valueMethod.Method
+}
+
+func samePath(a, b []graph.Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isToken reports whether s is an RFC 9110 token, as methods must be
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// NOTE: A prefix that is no method, as in "/a b", leaves the pattern a path
+func splitPattern(pattern string) (method, path string) {
+	if method, path, ok := strings.Cut(pattern, " "); ok && isToken(method) {
+		return method, strings.TrimLeft(path, " ")
+	}
+
+	return "", pattern
+}
+
+type methodSet map[string]struct{}
+
+func (ms methodSet) add(mh MethodHandler) {
+	ms[mh.Method] = struct{}{}
+
+	if mh.Method == http.MethodGet {
+		ms[http.MethodHead] = struct{}{}
+	}
+}
+
+func (ms methodSet) allow() string {
+	ms[http.MethodOptions] = struct{}{}
+
+	methods := make([]string, 0, len(ms))
+	for method := range ms {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	return strings.Join(methods, ", ")
+}
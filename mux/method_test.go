@@ -0,0 +1,203 @@
+package mux_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/graph"
+	"github.com/oligarch316/go-urlrouter/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveMethod(handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMuxMethod(t *testing.T) {
+	m := mux.New()
+
+	require.NoError(t, m.HandlePath("GET /users/:id", echoHandler("getUser")))
+	require.NoError(t, m.HandlePath("PUT /users/:uid", echoHandler("putUser")))
+	require.NoError(t, m.HandlePath("DELETE /users/:id", echoHandler("deleteUser")))
+	require.NoError(t, m.HandlePath("POST /items/*", echoHandler("postItems")))
+	require.NoError(t, m.HandlePath("/items/special", echoHandler("special")))
+	require.NoError(t, m.HandlePath("GET /files/*", echoHandler("getFiles")))
+	require.NoError(t, m.HandlePath("/files/:name", echoHandler("anyFile")))
+
+	subtests := []struct {
+		name, method, target string
+		expectedCode         int
+		expectedBody         string
+		expectedAllow        string
+	}{
+		{
+			name:         "get",
+			method:       http.MethodGet,
+			target:       "/users/123",
+			expectedCode: http.StatusOK,
+			expectedBody: "getUser map[id:123]",
+		},
+		{
+			name:         "put with own parameter names",
+			method:       http.MethodPut,
+			target:       "/users/123",
+			expectedCode: http.StatusOK,
+			expectedBody: "putUser map[uid:123]",
+		},
+		{
+			name:         "head falls back to get",
+			method:       http.MethodHead,
+			target:       "/users/123",
+			expectedCode: http.StatusOK,
+			expectedBody: "getUser map[id:123]",
+		},
+		{
+			name:          "method not allowed",
+			method:        http.MethodPost,
+			target:        "/users/123",
+			expectedCode:  http.StatusMethodNotAllowed,
+			expectedAllow: "DELETE, GET, HEAD, OPTIONS, PUT",
+		},
+		{
+			name:          "options",
+			method:        http.MethodOptions,
+			target:        "/users/123",
+			expectedCode:  http.StatusNoContent,
+			expectedAllow: "DELETE, GET, HEAD, OPTIONS, PUT",
+		},
+		{
+			name:         "more specific any method",
+			method:       http.MethodPost,
+			target:       "/items/special",
+			expectedCode: http.StatusOK,
+			expectedBody: "special map[]",
+		},
+		{
+			name:         "less specific any method",
+			method:       http.MethodPost,
+			target:       "/files/a",
+			expectedCode: http.StatusOK,
+			expectedBody: "anyFile map[name:a]",
+		},
+		{
+			name:          "method not allowed wildcard",
+			method:        http.MethodGet,
+			target:        "/items/a/b",
+			expectedCode:  http.StatusMethodNotAllowed,
+			expectedAllow: "OPTIONS, POST",
+		},
+		{
+			name:         "not found",
+			method:       http.MethodGet,
+			target:       "/missing",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			rec := serveMethod(m, st.method, st.target)
+
+			assert.Equal(t, st.expectedCode, rec.Code)
+			assert.Equal(t, st.expectedAllow, rec.Header().Get("Allow"))
+
+			if st.expectedBody != "" {
+				assert.Equal(t, st.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestMuxMethodHead(t *testing.T) {
+	m := mux.New()
+
+	require.NoError(t, m.HandlePath("GET /a", echoHandler("getA")))
+	require.NoError(t, m.HandlePath("HEAD /a", echoHandler("headA")))
+	require.NoError(t, m.HandlePath("GET /b/:id", echoHandler("getB")))
+	require.NoError(t, m.HandlePath("HEAD /*", echoHandler("headAny")))
+
+	subtests := []struct {
+		name, target, expectedBody string
+	}{
+		{name: "exact head after get", target: "/a", expectedBody: "headA map[]"},
+		{name: "get fallback before less specific head", target: "/b/1", expectedBody: "getB map[id:1]"},
+		{name: "head only", target: "/c", expectedBody: "headAny map[]"},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			rec := serveMethod(m, http.MethodHead, st.target)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, st.expectedBody, rec.Body.String())
+		})
+	}
+
+	assert.Equal(t, "getA map[]", serveMethod(m, http.MethodGet, "/a").Body.String())
+}
+
+func TestMuxMethodHeadHost(t *testing.T) {
+	m := mux.New()
+
+	require.NoError(t, m.Handle("api.example.com", "GET /x", echoHandler("getAPI")))
+	require.NoError(t, m.Handle(mux.HostAny, "HEAD /x", echoHandler("headAny")))
+
+	for host, expected := range map[string]string{
+		"api.example.com":   "getAPI map[]",
+		"other.example.com": "headAny map[]",
+	} {
+		req := httptest.NewRequest(http.MethodHead, "/x", nil)
+		req.Host = host
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+
+		assert.Equal(t, expected, rec.Body.String(), host)
+	}
+}
+
+func TestMuxMethodConflict(t *testing.T) {
+	var targetErr graph.DuplicateValueError[http.Handler]
+
+	m := mux.New()
+	require.NoError(t, m.HandlePath("GET /a", echoHandler("getA")))
+
+	assert.ErrorAs(t, m.HandlePath("GET /a", echoHandler("getAgain")), &targetErr)
+	assert.ErrorAs(t, m.HandlePath("/a", echoHandler("any")), &targetErr)
+
+	require.NoError(t, m.HandlePath("/b", echoHandler("any")))
+	assert.ErrorAs(t, m.HandlePath("GET /b", echoHandler("getB")), &targetErr)
+}
+
+func TestMuxMethodPatternSpace(t *testing.T) {
+	m := mux.New()
+	require.NoError(t, m.HandlePath("/a b", echoHandler("spaceAny")))
+	require.NoError(t, m.HandlePath("POST /c d", echoHandler("spacePost")))
+
+	assert.Equal(t, "spaceAny map[]", serveMethod(m, http.MethodPut, "/a%20b").Body.String())
+	assert.Equal(t, "spacePost map[]", serveMethod(m, http.MethodPost, "/c%20d").Body.String())
+	assert.Equal(t, http.StatusMethodNotAllowed, serveMethod(m, http.MethodGet, "/c%20d").Code)
+}
+
+func TestMuxMethodNotAllowedHandler(t *testing.T) {
+	m := mux.New()
+	m.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	require.NoError(t, m.HandlePath("GET /a", echoHandler("getA")))
+
+	rec := serveMethod(m, http.MethodPost, "/a")
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "GET, HEAD, OPTIONS", rec.Header().Get("Allow"))
+}
@@ -0,0 +1,266 @@
+package mux
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/component"
+	"github.com/oligarch316/go-urlrouter/graph"
+)
+
+const (
+	// HostAny matches requests regardless of host.
+	HostAny = "*"
+
+	// DefaultTailName is the path value name under which the tail of an unnamed
+	// wildcard is set.
+	DefaultTailName = "*"
+)
+
+type Result = graph.SearchResult[http.Handler, string]
+
+type resultKey struct{}
+
+func WithResult(ctx context.Context, result *Result) context.Context {
+	return context.WithValue(ctx, resultKey{}, result)
+}
+
+func ResultFromContext(ctx context.Context) (*Result, bool) {
+	result, ok := ctx.Value(resultKey{}).(*Result)
+	return result, ok
+}
+
+func Params(r *http.Request) map[string]string {
+	if result, ok := ResultFromContext(r.Context()); ok {
+		return result.Parameters
+	}
+
+	return nil
+}
+
+func Param(r *http.Request, name string) string { return Params(r)[name] }
+
+type Mux struct {
+	Router *component.URLRouter[http.Handler]
+
+	// NotFound handles requests matching no route, defaulting to http.NotFound
+	NotFound http.Handler
+
+	// MethodNotAllowed handles requests matching a route only under other
+	// methods, defaulting to a plain 405 response. The Allow header is set
+	// beforehand
+	MethodNotAllowed http.Handler
+
+	// BadRequest handles requests whose host or path cannot be segmented,
+	// defaulting to a plain 400 response
+	BadRequest func(w http.ResponseWriter, r *http.Request, err error)
+
+	// TailName is the path value name under which the tail of an unnamed
+	// wildcard is set, defaulting to DefaultTailName. A named wildcard, as in
+	// "/static/*rest", sets its tail under its own name
+	TailName string
+
+	// TrailingSlashRedirect, when set to a redirect status code, redirects
+	// requests matching no route to their other trailing slash form if that
+	// matches instead. Only meaningful with DistinctTrailingSlash
+	TrailingSlashRedirect int
+
+	// CleanPathRedirect, when set to a redirect status code, redirects
+	// requests whose path contains "." or ".." segments or repeated slashes to
+	// the cleaned path before routing
+	CleanPathRedirect int
+}
+
+func New(opts ...func(*Mux)) *Mux {
+	router := component.NewURLRouter[http.Handler]()
+	router.Coexist = coexistMethods
+
+	res := &Mux{Router: router}
+
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
+}
+
+// Handle registers handler for host and pattern, where pattern is either a
+// path or a method and path separated by a space, as in "GET /users/:id".
+func (m *Mux) Handle(host, pattern string, handler http.Handler) error {
+	method, path := splitPattern(pattern)
+
+	if name := patternTailName(path); name != "" {
+		handler = namedTail{name: name, Handler: handler}
+	}
+
+	if method != "" {
+		handler = MethodHandler{Method: method, Handler: handler}
+	}
+
+	return m.Router.Add(host, path, handler)
+}
+
+func (m *Mux) HandleFunc(host, pattern string, handler func(http.ResponseWriter, *http.Request)) error {
+	return m.Handle(host, pattern, http.HandlerFunc(handler))
+}
+
+func (m *Mux) HandlePath(pattern string, handler http.Handler) error {
+	return m.Handle(HostAny, pattern, handler)
+}
+
+func (m *Mux) HandlePathFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) error {
+	return m.HandlePath(pattern, http.HandlerFunc(handler))
+}
+
+func requestHost(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.Host); err == nil {
+		return host
+	}
+
+	return r.Host
+}
+
+func (m *Mux) requestPath(r *http.Request) string { return m.Router.URLPath(r.URL) }
+
+// NOTE: With EscapedPath, path is escaped and must not be escaped again
+func (m *Mux) redirect(w http.ResponseWriter, r *http.Request, path string, code int) {
+	target := url.URL{Path: path, RawQuery: r.URL.RawQuery}
+
+	if m.Router.EscapedPath() {
+		if unescaped, err := url.PathUnescape(path); err == nil {
+			target.Path, target.RawPath = unescaped, path
+		}
+	}
+
+	http.Redirect(w, r, target.String(), code)
+}
+
+func (m *Mux) notFound(w http.ResponseWriter, r *http.Request) {
+	if m.NotFound != nil {
+		m.NotFound.ServeHTTP(w, r)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (m *Mux) badRequest(w http.ResponseWriter, r *http.Request, err error) {
+	if m.BadRequest != nil {
+		m.BadRequest(w, r, err)
+		return
+	}
+
+	http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+}
+
+// NOTE: Path values mirror Result.Parameters, so handlers written against
+// http.ServeMux and (*http.Request).PathValue work unchanged.
+func (m *Mux) setPathValues(r *http.Request, result *Result) {
+	for name, value := range result.Parameters {
+		r.SetPathValue(name, value)
+	}
+
+	if len(result.Tail) > 0 {
+		tailName, ok := tailNameOf(result.Value)
+		if !ok {
+			tailName = m.TailName
+		}
+
+		if tailName == "" {
+			tailName = DefaultTailName
+		}
+
+		r.SetPathValue(tailName, strings.Join(result.Tail, "/"))
+	}
+}
+
+func (m *Mux) methodNotAllowed(w http.ResponseWriter, r *http.Request, allow string) {
+	w.Header().Set("Allow", allow)
+
+	if m.MethodNotAllowed != nil {
+		m.MethodNotAllowed.ServeHTTP(w, r)
+		return
+	}
+
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+func options(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NOTE: Results for other methods are passed over as though absent, while
+// their methods are collected so that a path match is still distinguishable
+// from no match at all. A HEAD request falls back to GET only when the same
+// route, whose values are visited consecutively, has no HEAD handler.
+func (m *Mux) lookup(r *http.Request, path string) (res *Result, others methodSet, err error) {
+	var (
+		fallback     *Result
+		fallbackHost int
+	)
+
+	err = m.Router.SearchHostFunc(func(hostRank int, result *Result) bool {
+		if fallback != nil && (fallbackHost != hostRank || !samePath(fallback.Path, result.Path)) {
+			return true
+		}
+
+		mh, ok := result.Value.(MethodHandler)
+		switch {
+		case !ok || mh.Method == r.Method:
+			res = result
+			return true
+		case r.Method == http.MethodHead && mh.Method == http.MethodGet:
+			fallback, fallbackHost = result, hostRank
+			return false
+		}
+
+		if others == nil {
+			others = make(methodSet)
+		}
+
+		others.add(mh)
+		return false
+	}, requestHost(r), path)
+
+	if res == nil && fallback != nil {
+		return fallback, nil, err
+	}
+
+	return res, others, err
+}
+
+// Lookup returns the highest priority result for r accepting its method, or
+// nil if none match.
+func (m *Mux) Lookup(r *http.Request) (*Result, error) {
+	result, _, err := m.lookup(r, m.requestPath(r))
+	return result, err
+}
+
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.redirectCleanPath(w, r) {
+		return
+	}
+
+	result, others, err := m.lookup(r, m.requestPath(r))
+
+	switch {
+	case err != nil:
+		m.badRequest(w, r, err)
+	case result == nil && others == nil && m.redirectTrailingSlash(w, r):
+		return
+	case result == nil && others == nil:
+		m.notFound(w, r)
+	case result == nil && r.Method == http.MethodOptions:
+		options(w, others.allow())
+	case result == nil:
+		m.methodNotAllowed(w, r, others.allow())
+	default:
+		r = r.WithContext(WithResult(r.Context(), result))
+		m.setPathValues(r, result)
+		result.Value.ServeHTTP(w, r)
+	}
+}
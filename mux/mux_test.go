@@ -0,0 +1,226 @@
+package mux_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoHandler(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %v", name, mux.Params(r))
+	}
+}
+
+func serve(handler http.Handler, host, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Host = host
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMux(t *testing.T) {
+	m := mux.New()
+
+	require.NoError(t, m.Handle(":sub.example.com", "/users/:id", echoHandler("subUser")))
+	require.NoError(t, m.HandleFunc("api.example.com", "/users/:id", echoHandler("apiUser")))
+	require.NoError(t, m.HandlePath("/static/*", echoHandler("static")))
+	require.NoError(t, m.HandlePathFunc("/", echoHandler("root")))
+
+	subtests := []struct {
+		name, host, target string
+		expectedCode       int
+		expectedBody       string
+	}{
+		{
+			name:         "constant host",
+			host:         "api.example.com",
+			target:       "/users/123",
+			expectedCode: http.StatusOK,
+			expectedBody: "apiUser map[id:123]",
+		},
+		{
+			name:         "parameter host with port",
+			host:         "x.example.com:8080",
+			target:       "/users/123?q=1",
+			expectedCode: http.StatusOK,
+			expectedBody: "subUser map[id:123 sub:x]",
+		},
+		{
+			name:         "any host",
+			host:         "other.org",
+			target:       "/static/a/b",
+			expectedCode: http.StatusOK,
+			expectedBody: "static map[]",
+		},
+		{
+			name:         "empty host",
+			host:         "",
+			target:       "/",
+			expectedCode: http.StatusOK,
+			expectedBody: "root map[]",
+		},
+		{
+			name:         "not found",
+			host:         "other.org",
+			target:       "/users/123",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, subtest := range subtests {
+		st := subtest
+
+		t.Run(st.name, func(t *testing.T) {
+			rec := serve(m, st.host, st.target)
+
+			assert.Equal(t, st.expectedCode, rec.Code)
+
+			if st.expectedBody != "" {
+				assert.Equal(t, st.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestMuxNotFound(t *testing.T) {
+	m := mux.New()
+	m.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rec := serve(m, "example.com", "/missing")
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+}
+
+func TestMuxResult(t *testing.T) {
+	m := mux.New()
+
+	require.NoError(t, m.HandleFunc("example.com", "/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		result, ok := mux.ResultFromContext(r.Context())
+		require.True(t, ok)
+
+		assert.Equal(t, "42", mux.Param(r, "id"))
+		assert.Equal(t, []string{"id"}, result.ParameterNames)
+	}))
+
+	rec := serve(m, "example.com", "/users/42")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, mux.Params(req))
+	assert.Empty(t, mux.Param(req, "id"))
+}
+
+func TestMuxBadRequest(t *testing.T) {
+	m := mux.New()
+	require.NoError(t, m.HandlePath("/", echoHandler("root")))
+
+	req := httptest.NewRequest(http.MethodOptions, "*", nil)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual error
+	m.BadRequest = func(w http.ResponseWriter, r *http.Request, err error) {
+		actual = err
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Error(t, actual)
+}
+
+func TestMuxPathValue(t *testing.T) {
+	pathValueHandler := func(names ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			for _, name := range names {
+				fmt.Fprintf(w, "%s=%s;", name, r.PathValue(name))
+			}
+		}
+	}
+
+	t.Run("parameters", func(t *testing.T) {
+		m := mux.New()
+		require.NoError(t, m.Handle(":sub.example.com", "/users/:id", pathValueHandler("sub", "id", "*")))
+
+		rec := serve(m, "x.example.com", "/users/123")
+		assert.Equal(t, "sub=x;id=123;*=;", rec.Body.String())
+	})
+
+	t.Run("tail", func(t *testing.T) {
+		m := mux.New()
+		require.NoError(t, m.HandlePath("/static/:dir/*", pathValueHandler("dir", "*")))
+
+		rec := serve(m, "example.com", "/static/css/a/b.css")
+		assert.Equal(t, "dir=css;*=a/b.css;", rec.Body.String())
+	})
+
+	t.Run("tail name", func(t *testing.T) {
+		m := mux.New()
+		m.TailName = "rest"
+		require.NoError(t, m.HandlePath("/static/*", pathValueHandler("rest", "*")))
+
+		rec := serve(m, "example.com", "/static/a/b.css")
+		assert.Equal(t, "rest=a/b.css;*=;", rec.Body.String())
+	})
+
+	t.Run("named tail", func(t *testing.T) {
+		m := mux.New()
+		require.NoError(t, m.HandlePath("/static/*rest", pathValueHandler("rest", "*")))
+		require.NoError(t, m.HandlePath("GET /files/*path", pathValueHandler("path", "*")))
+
+		assert.Equal(t, "rest=a/b.css;*=;", serve(m, "example.com", "/static/a/b.css").Body.String())
+		assert.Equal(t, "path=a/b;*=;", serve(m, "example.com", "/files/a/b").Body.String())
+	})
+
+	t.Run("serve mux tail parity", func(t *testing.T) {
+		var (
+			handler  = pathValueHandler("rest")
+			serveMux = http.NewServeMux()
+			m        = mux.New()
+		)
+
+		serveMux.Handle("/static/{rest...}", handler)
+		require.NoError(t, m.HandlePath("/static/*rest", handler))
+
+		expected := serve(serveMux, "example.com", "/static/a/b.css")
+		actual := serve(m, "example.com", "/static/a/b.css")
+		assert.Equal(t, expected.Body.String(), actual.Body.String())
+	})
+
+	t.Run("serve mux parity", func(t *testing.T) {
+		var (
+			handler  = pathValueHandler("id")
+			serveMux = http.NewServeMux()
+			m        = mux.New()
+		)
+
+		serveMux.Handle("/users/{id}", handler)
+		require.NoError(t, m.HandlePath("/users/:id", handler))
+
+		expected := serve(serveMux, "example.com", "/users/123")
+		actual := serve(m, "example.com", "/users/123")
+		assert.Equal(t, expected.Body.String(), actual.Body.String())
+	})
+}
+
+func TestMuxStrictHost(t *testing.T) {
+	m := mux.New(mux.StrictHost)
+	require.NoError(t, m.Handle("api.example.com", "/", echoHandler("api")))
+	require.NoError(t, m.Handle("[::1]", "/", echoHandler("loopback")))
+
+	assert.Equal(t, "api map[]", serve(m, "API.Example.com.:8080", "/").Body.String())
+	assert.Equal(t, "loopback map[]", serve(m, "[::1]:8080", "/").Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve(m, "api..example.com", "/").Code)
+}
@@ -0,0 +1,41 @@
+package mux
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/oligarch316/go-urlrouter/component"
+)
+
+// DistinctTrailingSlash makes "/a" and "/a/" distinct routes.
+func DistinctTrailingSlash(m *Mux) { component.URLDistinctTrailingSlash(m.Router) }
+
+func toggleTrailingSlash(path string) (string, bool) {
+	switch {
+	// NOTE: A leading "//" would redirect to another host
+	case path == "/", strings.HasPrefix(path, "//"):
+		return "", false
+	case strings.HasSuffix(path, "/"):
+		return strings.TrimSuffix(path, "/"), true
+	}
+
+	return path + "/", true
+}
+
+func (m *Mux) redirectTrailingSlash(w http.ResponseWriter, r *http.Request) bool {
+	if m.TrailingSlashRedirect == 0 {
+		return false
+	}
+
+	path, ok := toggleTrailingSlash(m.requestPath(r))
+	if !ok {
+		return false
+	}
+
+	if result, others, err := m.lookup(r, path); err != nil || (result == nil && others == nil) {
+		return false
+	}
+
+	m.redirect(w, r, path, m.TrailingSlashRedirect)
+	return true
+}
@@ -0,0 +1,90 @@
+package mux_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oligarch316/go-urlrouter/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuxTrailingSlash(t *testing.T) {
+	register := func(t *testing.T, m *mux.Mux) {
+		require.NoError(t, m.HandlePath("/a", echoHandler("a")))
+		require.NoError(t, m.HandlePath("/b/", echoHandler("bSlash")))
+		require.NoError(t, m.HandlePath("POST /c/:id/", echoHandler("cSlash")))
+	}
+
+	t.Run("default", func(t *testing.T) {
+		m := mux.New()
+		register(t, m)
+
+		assert.Equal(t, "a map[]", serve(m, "example.com", "/a/").Body.String())
+		assert.Equal(t, "bSlash map[]", serve(m, "example.com", "/b").Body.String())
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		m := mux.New(mux.DistinctTrailingSlash)
+		register(t, m)
+
+		assert.Equal(t, "a map[]", serve(m, "example.com", "/a").Body.String())
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/a/").Code)
+		assert.Equal(t, "bSlash map[]", serve(m, "example.com", "/b/").Body.String())
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/b").Code)
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		m := mux.New(mux.DistinctTrailingSlash)
+		m.TrailingSlashRedirect = http.StatusPermanentRedirect
+		register(t, m)
+
+		subtests := []struct {
+			name, target, expectedLocation string
+			expectedCode                   int
+		}{
+			{name: "add slash", target: "/b?q=1", expectedLocation: "/b/?q=1", expectedCode: http.StatusPermanentRedirect},
+			{name: "remove slash", target: "/a/", expectedLocation: "/a", expectedCode: http.StatusPermanentRedirect},
+			{name: "other method", target: "/c/1", expectedLocation: "/c/1/", expectedCode: http.StatusPermanentRedirect},
+			{name: "exact", target: "/a", expectedCode: http.StatusOK},
+			{name: "neither", target: "/d", expectedCode: http.StatusNotFound},
+			{name: "root", target: "/", expectedCode: http.StatusNotFound},
+		}
+
+		for _, subtest := range subtests {
+			st := subtest
+
+			t.Run(st.name, func(t *testing.T) {
+				rec := serve(m, "example.com", st.target)
+
+				assert.Equal(t, st.expectedCode, rec.Code)
+				assert.Equal(t, st.expectedLocation, rec.Header().Get("Location"))
+			})
+		}
+	})
+}
+
+func TestMuxTrailingSlashParameter(t *testing.T) {
+	t.Run("without slash route", func(t *testing.T) {
+		m := mux.New(mux.DistinctTrailingSlash)
+		m.TrailingSlashRedirect = http.StatusMovedPermanently
+		require.NoError(t, m.HandlePath("/users", echoHandler("users")))
+		require.NoError(t, m.HandlePath("/users/:id", echoHandler("user")))
+
+		rec := serve(m, "example.com", "/users/")
+		assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+		assert.Equal(t, "/users", rec.Header().Get("Location"))
+
+		assert.Equal(t, "user map[id:1]", serve(m, "example.com", "/users/1").Body.String())
+	})
+
+	t.Run("with slash route", func(t *testing.T) {
+		m := mux.New(mux.DistinctTrailingSlash)
+		require.NoError(t, m.HandlePath("/users/", echoHandler("usersSlash")))
+		require.NoError(t, m.HandlePath("/users/:id", echoHandler("user")))
+
+		assert.Equal(t, "usersSlash map[]", serve(m, "example.com", "/users/").Body.String())
+		assert.Equal(t, "user map[id:1]", serve(m, "example.com", "/users/1").Body.String())
+		assert.Equal(t, http.StatusNotFound, serve(m, "example.com", "/users").Code)
+	})
+}
@@ -0,0 +1,32 @@
+package mux
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NOTE: Wildcard keys carry no name, so the name given in a pattern such as
+// "/static/*rest" is kept alongside its handler instead.
+type namedTail struct {
+	name string
+	http.Handler
+}
+
+func patternTailName(path string) string {
+	last := path[strings.LastIndexByte(path, '/')+1:]
+
+	if len(last) > 1 && last[0] == '*' {
+		return last[1:]
+	}
+
+	return ""
+}
+
+func tailNameOf(handler http.Handler) (string, bool) {
+	if mh, ok := handler.(MethodHandler); ok {
+		handler = mh.Handler
+	}
+
+	nt, ok := handler.(namedTail)
+	return nt.name, ok
+}